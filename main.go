@@ -2,28 +2,123 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"embed"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// releaseVersion is set at release time with
+// -ldflags "-X main.releaseVersion=v1.2.3".
+var releaseVersion = ""
+
+// toolVersion reports the release version, falling back to the module
+// version or VCS revision embedded by the Go toolchain.
+func toolVersion() string {
+	if releaseVersion != "" {
+		return releaseVersion
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && setting.Value != "" {
+			revision := setting.Value
+			if len(revision) > 12 {
+				revision = revision[:12]
+			}
+			return "devel-" + revision
+		}
+	}
+	return "devel"
+}
+
+const (
+	rejectMissingID       = "missing_id"
+	rejectUnparseableDate = "unparseable_date"
+	rejectFutureDate      = "future_date"
+	rejectDuplicateDay    = "duplicate_same_day"
 )
 
 const (
 	defaultCadenceDays = 30
 	defaultTopN        = 10
+	tierNeverContacted = "never_contacted"
+	tierScheduled      = "scheduled"
+	defaultDBTimeout   = 2 * time.Minute
+	copyProgressEvery  = 5000
+)
+
+// Store modes decide what happens when a run with the same input
+// fingerprint is already stored.
+const (
+	storeModeAppend  = "append"
+	storeModeSkip    = "skip"
+	storeModeReplace = "replace"
+
+	snoozeModeSuppress = "suppress"
+	snoozeModeMark     = "mark"
+
+	alertReasonNew       = "new"
+	alertReasonEscalated = "escalated"
+)
+
+var (
+	scholarIDAliases  = []string{"scholar_id", "scholarid", "scholar", "student_id", "studentid"}
+	programAliases    = []string{"program", "cohort", "track"}
+	enrollmentAliases = []string{"enrollment_date", "enrolled_on", "enrolled_at", "enrolled", "start_date"}
+	ownerAliases      = []string{"owner", "advisor", "staff", "case_manager"}
+	snoozeAliases     = []string{"snooze_until", "snoozed_until", "until", "snooze_end"}
+	reasonAliases     = []string{"reason", "note", "notes"}
+	ackedByAliases    = []string{"acked_by", "acknowledged_by", "ack_by"}
+	startAliases      = []string{"start_date", "start", "from", "starts_on"}
+	endAliases        = []string{"end_date", "end", "to", "ends_on"}
+	nameAliases       = []string{"name", "label", "description", "period"}
+	holidayAliases    = []string{"date", "holiday_date", "holiday", "day"}
+
+	// touchpointFields lists the logical touchpoint columns and their
+	// built-in header aliases, in lookup order.
+	touchpointFields = map[string][]string{
+		"scholar_id":   scholarIDAliases,
+		"contact_date": {"contact_date", "contacted_at", "date", "touchpoint_date", "touchpoint"},
+		"program":      programAliases,
+		"channel":      {"channel", "method", "touchpoint_channel"},
+		"status":       {"status", "outcome", "result"},
+		"owner":        ownerAliases,
+	}
+	// touchpointFieldOrder fixes the order fields are resolved and
+	// validated in, so mapping errors are reported deterministically.
+	touchpointFieldOrder = []string{"scholar_id", "contact_date", "program", "channel", "status", "owner"}
 )
 
 type ScholarStats struct {
@@ -37,64 +132,322 @@ type ScholarStats struct {
 	Channels     map[string]int
 	Contacts     []time.Time
 	ContactDates map[string]struct{}
+	Owner        string
+	OwnerSeen    time.Time
+	Enrolled     time.Time
+	LastSuccess  time.Time
+	LastSource   string
+	Scheduled    time.Time
+	Attempts     []contactAttempt
+	Extra        map[string]string
+	ExtraSeen    map[string]time.Time
+}
+
+type contactAttempt struct {
+	Date    time.Time
+	Success bool
+}
+
+type RosterEntry struct {
+	ScholarID      string
+	Program        string
+	Owner          string
+	EnrollmentDate time.Time
+}
+
+// Exclusion takes a scholar out of the audit from Start through End
+// (inclusive). A zero Start or End leaves that side open.
+type Exclusion struct {
+	ScholarID string
+	Start     time.Time
+	End       time.Time
+	Reason    string
+}
+
+type ExcludedScholar struct {
+	ScholarID string    `json:"scholar_id"`
+	Program   string    `json:"program,omitempty"`
+	Owner     string    `json:"owner,omitempty"`
+	Reason    string    `json:"reason"`
+	Start     time.Time `json:"start_date,omitzero"`
+	End       time.Time `json:"end_date,omitzero"`
+}
+
+// Blackout is an academic calendar break, such as summer recess, whose days
+// pause the cadence clock. An empty Program applies to every program.
+type Blackout struct {
+	Name    string    `json:"name,omitempty"`
+	Program string    `json:"program,omitempty"`
+	Start   time.Time `json:"start_date"`
+	End     time.Time `json:"end_date"`
+}
+
+type BlackoutCalendar []Blackout
+
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name,omitempty"`
+}
+
+// dateRange is an inclusive span of days during which the cadence clock
+// stops.
+type dateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Ack records an advisor acknowledging a scholar's alert and snoozing it
+// through SnoozeUntil (inclusive).
+type Ack struct {
+	ScholarID   string
+	SnoozeUntil time.Time
+	Reason      string
+	AckedBy     string
+}
+
+type CadenceRule struct {
+	CadenceDays   int `json:"cadence_days"`
+	DueWindowDays int `json:"due_window_days"`
+}
+
+type CadencePolicy struct {
+	Default  CadenceRule            `json:"default"`
+	Programs map[string]CadenceRule `json:"programs"`
+}
+
+type ColumnField struct {
+	Column  string   `json:"column"`
+	Aliases []string `json:"aliases"`
+}
+
+// ColumnMapping names source columns for logical fields and lists extra
+// passthrough columns (output name -> source header) to carry into reports.
+type ColumnMapping struct {
+	Fields      map[string]ColumnField `json:"fields"`
+	Passthrough map[string]string      `json:"passthrough"`
+}
+
+type AuditOptions struct {
+	AsOf          time.Time
+	CadenceDays   int
+	DueWindowDays int
+	TopN          int
+	DedupeDay     bool
+	Roster        map[string]RosterEntry
+	Policy        *CadencePolicy
+	Ladder        *TierLadder
+	Columns       *ColumnMapping
+	// Location converts timestamps before day truncation; nil keeps each
+	// value's own offset (UTC for plain dates).
+	Location *time.Location
+	// ScheduleFuture keeps rows dated after the as-of date as booked
+	// follow-ups instead of rejecting them.
+	ScheduleFuture bool
+	// SkipFuture drops rows dated after the as-of date without rejecting
+	// them; replay uses it so later history is not reported as bad data.
+	SkipFuture bool
+	// MinTier is the alert threshold, recorded with the run.
+	MinTier string
+	// SuccessStatuses holds normalized status values that reset the cadence
+	// clock; when empty every touchpoint counts as a successful contact.
+	SuccessStatuses map[string]bool
+	// Acks snoozes scholars until a date; SnoozeMode decides whether snoozed
+	// scholars are dropped from the audit or only flagged.
+	Acks       map[string]Ack
+	SnoozeMode string
+	// Exclusions removes scholars during an active exclusion and pauses
+	// their cadence clock for exclusions that have already ended.
+	Exclusions map[string][]Exclusion
+	// Calendar lists blackout periods whose days pause the cadence clock.
+	Calendar BlackoutCalendar
+	// BusinessDays counts cadence, due window, and gaps in working days,
+	// skipping weekends and Holidays.
+	BusinessDays bool
+	Holidays     []Holiday
 }
 
 type ScholarSummary struct {
-	ScholarID        string    `json:"scholar_id"`
-	Program          string    `json:"program"`
-	LastChannel      string    `json:"last_channel"`
-	LastStatus       string    `json:"last_status"`
-	LastContact      time.Time `json:"last_contact"`
-	FirstContact     time.Time `json:"first_contact"`
-	NextDueDate      time.Time `json:"next_due_date"`
-	ContactCount     int       `json:"contact_count"`
-	GapDays          int       `json:"gap_days"`
-	DaysPastDue      int       `json:"days_past_due"`
-	MissedCadences   int       `json:"missed_cadences"`
-	DaysSinceFirst   int       `json:"days_since_first_contact"`
-	AvgIntervalDays  float64   `json:"avg_interval_days"`
-	ContactsPerMonth float64   `json:"contacts_per_month"`
-	Tier             string    `json:"tier"`
+	ScholarID        string            `json:"scholar_id"`
+	Program          string            `json:"program"`
+	Owner            string            `json:"owner,omitempty"`
+	EnrollmentDate   time.Time         `json:"enrollment_date,omitzero"`
+	LastChannel      string            `json:"last_channel"`
+	LastStatus       string            `json:"last_status"`
+	LastSource       string            `json:"last_source,omitempty"`
+	LastContact      time.Time         `json:"last_contact"`
+	LastSuccess      time.Time         `json:"last_successful_contact"`
+	FirstContact     time.Time         `json:"first_contact"`
+	NextDueDate      time.Time         `json:"next_due_date"`
+	ScheduledDate    time.Time         `json:"scheduled_date,omitzero"`
+	ContactCount     int               `json:"contact_count"`
+	FailedAttempts   int               `json:"failed_attempts"`
+	SinceSuccess     int               `json:"attempts_since_success"`
+	FailedStreak     int               `json:"consecutive_failed_attempts"`
+	GapDays          int               `json:"gap_days"`
+	RawGapDays       int               `json:"raw_gap_days"`
+	DaysPastDue      int               `json:"days_past_due"`
+	MissedCadences   int               `json:"missed_cadences"`
+	CadenceDays      int               `json:"cadence_days"`
+	DueWindowDays    int               `json:"due_window_days"`
+	DaysSinceFirst   int               `json:"days_since_first_contact"`
+	AvgIntervalDays  float64           `json:"avg_interval_days"`
+	ContactsPerMonth float64           `json:"contacts_per_month"`
+	Tier             string            `json:"tier"`
+	TierSince        time.Time         `json:"tier_since,omitzero"`
+	DaysInTier       *int              `json:"days_in_tier,omitempty"`
+	SnoozedUntil     time.Time         `json:"snoozed_until,omitzero"`
+	SnoozeReason     string            `json:"snooze_reason,omitempty"`
+	AckedBy          string            `json:"acked_by,omitempty"`
+	AlertReason      string            `json:"alert_reason,omitempty"`
+	PausedDays       int               `json:"paused_days,omitempty"`
+	Extra            map[string]string `json:"extra,omitempty"`
 }
 
 type ProgramSummary struct {
-	Program           string  `json:"program"`
-	Scholars          int     `json:"scholars"`
-	AvgGapDays        float64 `json:"avg_gap_days"`
-	AvgMissedCadences float64 `json:"avg_missed_cadences"`
-	OverdueCount      int     `json:"overdue_count"`
-	CriticalCount     int     `json:"critical_count"`
-	OnTrackCount      int     `json:"on_track_count"`
-	DueSoonCount      int     `json:"due_soon_count"`
+	Program           string      `json:"program"`
+	CadenceDays       int         `json:"cadence_days"`
+	DueWindowDays     int         `json:"due_window_days"`
+	Scholars          int         `json:"scholars"`
+	AvgGapDays        float64     `json:"avg_gap_days"`
+	AvgMissedCadences float64     `json:"avg_missed_cadences"`
+	TierCounts        []TierCount `json:"tier_counts"`
+}
+
+type OwnerSummary struct {
+	Owner             string      `json:"owner"`
+	Scholars          int         `json:"scholars"`
+	AvgGapDays        float64     `json:"avg_gap_days"`
+	AvgMissedCadences float64     `json:"avg_missed_cadences"`
+	TierCounts        []TierCount `json:"tier_counts"`
 }
 
 type ReportSummary struct {
-	AsOf              string  `json:"as_of"`
-	CadenceDays       int     `json:"cadence_days"`
-	DueWindowDays     int     `json:"due_window_days"`
-	TotalScholars     int     `json:"total_scholars"`
-	AvgGapDays        float64 `json:"avg_gap_days"`
-	MedianGapDays     float64 `json:"median_gap_days"`
-	MaxGapDays        int     `json:"max_gap_days"`
-	AvgMissedCadences float64 `json:"avg_missed_cadences"`
-	MaxMissedCadences int     `json:"max_missed_cadences"`
-	OnTrackCount      int     `json:"on_track_count"`
-	DueSoonCount      int     `json:"due_soon_count"`
-	OverdueCount      int     `json:"overdue_count"`
-	CriticalCount     int     `json:"critical_count"`
-	InvalidRows       int     `json:"invalid_rows"`
-	FutureRows        int     `json:"future_rows"`
+	AsOf              string         `json:"as_of"`
+	Timezone          string         `json:"timezone,omitempty"`
+	CadenceDays       int            `json:"cadence_days"`
+	DueWindowDays     int            `json:"due_window_days"`
+	PolicyPrograms    int            `json:"policy_programs"`
+	TotalScholars     int            `json:"total_scholars"`
+	AvgGapDays        float64        `json:"avg_gap_days"`
+	MedianGapDays     float64        `json:"median_gap_days"`
+	MaxGapDays        int            `json:"max_gap_days"`
+	AvgMissedCadences float64        `json:"avg_missed_cadences"`
+	MaxMissedCadences int            `json:"max_missed_cadences"`
+	TierCounts        []TierCount    `json:"tier_counts"`
+	RosterScholars    int            `json:"roster_scholars"`
+	SuccessStatuses   []string       `json:"success_statuses,omitempty"`
+	FailedAttempts    int            `json:"failed_attempts"`
+	InputRows         int            `json:"input_rows"`
+	InvalidRows       int            `json:"invalid_rows"`
+	FutureRows        int            `json:"future_rows"`
+	ScheduleFuture    bool           `json:"schedule_future"`
+	RejectedRows      int            `json:"rejected_rows"`
+	RejectRate        float64        `json:"reject_rate"`
+	RejectReasons     map[string]int `json:"reject_reasons"`
+	DuplicateRows     int            `json:"duplicate_rows"`
+	InputFingerprint  string         `json:"input_fingerprint"`
+	DedupeDay         bool           `json:"dedupe_day"`
+	TopN              int            `json:"top_n"`
+	MinTier           string         `json:"min_tier,omitempty"`
+	ToolVersion       string         `json:"tool_version"`
+	SnoozeMode        string         `json:"snooze_mode,omitempty"`
+	SnoozedScholars   int            `json:"snoozed_scholars"`
+	SnoozeReasons     map[string]int `json:"snooze_reasons,omitempty"`
+	AlertChanges      *AlertChanges  `json:"alert_changes,omitempty"`
+	ExcludedScholars  int            `json:"excluded_scholars"`
+	ExcludedReasons   map[string]int `json:"excluded_reasons,omitempty"`
+	BlackoutPeriods   int            `json:"blackout_periods,omitempty"`
+	BusinessDays      bool           `json:"business_days"`
+}
+
+// RunParameters keeps the configuration files behind a report so a stored
+// run can be reproduced.
+type RunParameters struct {
+	Policy   *CadencePolicy   `json:"policy,omitempty"`
+	Ladder   *TierLadder      `json:"tier_ladder,omitempty"`
+	Columns  *ColumnMapping   `json:"columns,omitempty"`
+	Calendar BlackoutCalendar `json:"calendar,omitempty"`
+	Holidays []Holiday        `json:"holidays,omitempty"`
+}
+
+type TierCount struct {
+	Tier  string `json:"tier"`
+	Count int    `json:"count"`
+}
+
+// ReplayStep advances a replay by whole days or calendar months.
+type ReplayStep struct {
+	Days   int
+	Months int
+}
+
+type ReplaySnapshot struct {
+	Summary        ReportSummary    `json:"summary"`
+	ProgramSummary []ProgramSummary `json:"program_summary"`
+	report         Report
+}
+
+type Replay struct {
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Step      string           `json:"step"`
+	Tiers     []string         `json:"tiers"`
+	Snapshots []ReplaySnapshot `json:"snapshots"`
+}
+
+// TierDefinition bounds a tier by absolute gap days or by a multiple of the
+// scholar's cadence (optionally plus the due window). The last tier in a
+// ladder is the catch-all and carries no bound.
+type TierDefinition struct {
+	Name               string   `json:"name"`
+	MaxDays            *int     `json:"max_days,omitempty"`
+	MaxCadenceMultiple *float64 `json:"max_cadence_multiple,omitempty"`
+	PlusDueWindow      bool     `json:"plus_due_window,omitempty"`
+}
+
+type TierLadder struct {
+	Tiers []TierDefinition `json:"tiers"`
+}
+
+// RejectedRow records a touchpoint row that did not count toward the audit.
+type RejectedRow struct {
+	Source      string   `json:"source"`
+	Line        int      `json:"line"`
+	Reason      string   `json:"reason"`
+	ScholarID   string   `json:"scholar_id"`
+	ContactDate string   `json:"contact_date"`
+	Raw         []string `json:"raw"`
+}
+
+type SourceSummary struct {
+	Path          string `json:"path"`
+	Rows          int    `json:"rows"`
+	AcceptedRows  int    `json:"accepted_rows"`
+	InvalidRows   int    `json:"invalid_rows"`
+	FutureRows    int    `json:"future_rows"`
+	ScheduledRows int    `json:"scheduled_rows"`
+	RejectedRows  int    `json:"rejected_rows"`
+	DuplicateRows int    `json:"duplicate_rows"`
+	SHA256        string `json:"sha256"`
 }
 
 type Report struct {
 	Summary        ReportSummary      `json:"summary"`
+	Parameters     RunParameters      `json:"parameters"`
+	Tiers          []string           `json:"tiers"`
+	ExtraFields    []string           `json:"extra_fields,omitempty"`
+	Sources        []SourceSummary    `json:"sources"`
 	ProgramSummary []ProgramSummary   `json:"program_summary"`
+	OwnerSummary   []OwnerSummary     `json:"owner_summary,omitempty"`
 	ChannelSummary map[string]int     `json:"last_channel_summary"`
 	StatusSummary  map[string]int     `json:"last_status_summary"`
 	DueSummary     []DueBucketSummary `json:"due_summary"`
 	RecencySummary []RecencyBucket    `json:"recency_summary"`
 	TopGaps        []ScholarSummary   `json:"top_gaps"`
 	Scholars       []ScholarSummary   `json:"scholars"`
+	Snoozed        []ScholarSummary   `json:"snoozed,omitempty"`
+	Excluded       []ExcludedScholar  `json:"excluded,omitempty"`
+	Rejects        []RejectedRow      `json:"-"`
 }
 
 type DueBucketSummary struct {
@@ -115,39 +468,247 @@ type DBConfig struct {
 	URL    string
 	Schema string
 	Tag    string
+	// Timeout bounds each database operation; zero means defaultDBTimeout.
+	Timeout time.Duration
+	// Progress receives bulk-load progress lines when set.
+	Progress io.Writer
+	// Mode is one of storeModeAppend, storeModeSkip, or storeModeReplace.
+	Mode string
+}
+
+// ExportPaths names the optional report artifacts to write; empty paths are
+// skipped.
+type ExportPaths struct {
+	JSON     string
+	Alerts   string
+	MinTier  string
+	Programs string
+	Owners   string
+	Channels string
+	Statuses string
+	Due      string
+	Recency  string
+	// AlertReasons, when set, limits the alerts CSV to these scholars and
+	// labels each row with why it was alerted.
+	AlertReasons map[string]string
+}
+
+type StoredRun struct {
+	RunID    string
+	Skipped  bool
+	Replaced int64
+	// TierSince is the as-of date each scholar entered their current tier,
+	// according to the tier history stored before this run.
+	TierSince map[string]time.Time
+}
+
+func (cfg DBConfig) timeout() time.Duration {
+	if cfg.Timeout <= 0 {
+		return defaultDBTimeout
+	}
+	return cfg.Timeout
+}
+
+// HistoryFilter narrows the stored runs returned by the history subcommand.
+type HistoryFilter struct {
+	Tag   string
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+const (
+	changeWorsened = "worsened"
+	changeImproved = "improved"
+	changeNew      = "new"
+	changeRemoved  = "removed"
+	// changeUnranked marks a tier change involving a tier neither ladder
+	// lists, so its direction is unknown.
+	changeUnranked = "unranked"
+)
+
+type ScholarChange struct {
+	ScholarID      string `json:"scholar_id"`
+	Program        string `json:"program"`
+	Owner          string `json:"owner,omitempty"`
+	Change         string `json:"change"`
+	FromTier       string `json:"from_tier,omitempty"`
+	ToTier         string `json:"to_tier,omitempty"`
+	FromGapDays    int    `json:"from_gap_days"`
+	ToGapDays      int    `json:"to_gap_days"`
+	TierRankChange int    `json:"tier_rank_change"`
+}
+
+type TierDelta struct {
+	Tier    string `json:"tier"`
+	Base    int    `json:"base"`
+	Current int    `json:"current"`
+	Delta   int    `json:"delta"`
+}
+
+type ProgramDelta struct {
+	Program         string      `json:"program"`
+	BaseScholars    int         `json:"base_scholars"`
+	CurrentScholars int         `json:"current_scholars"`
+	Worsened        int         `json:"worsened"`
+	Improved        int         `json:"improved"`
+	New             int         `json:"new"`
+	Removed         int         `json:"removed"`
+	TierDeltas      []TierDelta `json:"tier_deltas"`
+}
+
+type ComparisonSide struct {
+	Source   string `json:"source"`
+	AsOf     string `json:"as_of"`
+	Scholars int    `json:"scholars"`
+}
+
+type Comparison struct {
+	Base         ComparisonSide  `json:"base"`
+	Current      ComparisonSide  `json:"current"`
+	Tiers        []string        `json:"tiers"`
+	UnknownTiers []string        `json:"unknown_tiers,omitempty"`
+	ChangeCounts map[string]int  `json:"change_counts"`
+	TierDeltas   []TierDelta     `json:"tier_deltas"`
+	Programs     []ProgramDelta  `json:"programs"`
+	Changes      []ScholarChange `json:"changes"`
+}
+
+type HistoryRun struct {
+	RunID          string           `json:"run_id"`
+	AsOf           string           `json:"as_of"`
+	Tag            string           `json:"tag,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	TotalScholars  int              `json:"total_scholars"`
+	AvgGapDays     float64          `json:"avg_gap_days"`
+	MaxGapDays     int              `json:"max_gap_days"`
+	TierCounts     []TierCount      `json:"tier_counts"`
+	ProgramSummary []ProgramSummary `json:"program_summary,omitempty"`
 }
 
 func main() {
-	inputPath := flag.String("input", "", "Path to outreach CSV")
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		if err := runHistory(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		if err := runCompare(os.Args[2:]); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+
+	var inputs stringList
+	flag.Var(&inputs, "input", "Path, directory, or glob of outreach CSVs (repeatable)")
 	cadenceDays := flag.Int("cadence", defaultCadenceDays, "Expected cadence in days")
 	asOf := flag.String("as-of", "", "Report as-of date (YYYY-MM-DD)")
+	timezone := flag.String("timezone", "", "IANA timezone applied to timestamps before day truncation (e.g. America/Chicago)")
 	dueWindow := flag.Int("due-window", 0, "Days after cadence before overdue; default cadence/2")
 	topN := flag.Int("top", defaultTopN, "Top N largest gaps to show")
 	dedupeDay := flag.Bool("dedupe-day", false, "Deduplicate multiple contacts on the same day per scholar")
+	scheduleFuture := flag.Bool("schedule-future", false, "Treat touchpoints after --as-of as scheduled follow-ups")
 	jsonOut := flag.String("json", "", "Optional JSON output path")
 	alertsOut := flag.String("alerts", "", "Optional CSV output for alert tiers")
 	programsOut := flag.String("programs-csv", "", "Optional CSV output for program summary")
+	ownersOut := flag.String("owners-csv", "", "Optional CSV output for owner caseload summary")
 	channelsOut := flag.String("channels-csv", "", "Optional CSV output for channel summary")
 	statusesOut := flag.String("statuses-csv", "", "Optional CSV output for last status summary")
 	dueOut := flag.String("due-csv", "", "Optional CSV output for due-date buckets")
 	recencyOut := flag.String("recency-csv", "", "Optional CSV output for recency buckets")
-	minTier := flag.String("min-tier", "overdue", "Minimum tier for alerts (due_soon, overdue, critical)")
-	dbEnabled := flag.Bool("db", false, "Store report in Postgres (requires TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL)")
+	rejectsOut := flag.String("rejects-csv", "", "Optional CSV output listing rejected rows with reason codes")
+	strict := flag.Bool("strict", false, "Fail the run when the reject rate exceeds --max-reject-rate")
+	maxRejectRate := flag.Float64("max-reject-rate", 0.05, "Maximum share of rejected rows tolerated in --strict mode (0-1)")
+	policyPath := flag.String("policy", "", "Optional CSV of per-program cadence policy (program, cadence_days, due_window_days)")
+	rosterPath := flag.String("roster", "", "Optional roster CSV of enrolled scholars (scholar_id, program, enrollment_date, owner)")
+	acksPath := flag.String("acks", "", "Optional CSV of acknowledged alerts (scholar_id, snooze_until, reason, acked_by)")
+	businessDays := flag.Bool("business-days", false, "Count cadence, due window, gaps, and due dates in working days (Monday-Friday)")
+	holidaysPath := flag.String("holidays", "", "Optional CSV of holidays skipped in --business-days mode (date, name)")
+	calendarPath := flag.String("calendar", "", "Optional CSV of blackout periods that pause the cadence clock (start_date, end_date, program, name)")
+	exclusionsPath := flag.String("exclusions", "", "Optional CSV of scholars on leave, graduated, or withdrawn (scholar_id, start_date, end_date, reason)")
+	snoozeMode := flag.String("snooze-mode", snoozeModeSuppress, "How snoozed scholars appear: suppress (drop from alerts, top gaps, and tier counts) or mark")
+	successStatuses := flag.String("success-statuses", "", "Comma-separated statuses that count as successful contacts (default: all)")
+	columnsPath := flag.String("columns", "", "Optional JSON column mapping for CRM exports")
+	tiersPath := flag.String("tiers", "", "Optional JSON tier ladder definition")
+	minTier := flag.String("min-tier", "overdue", "Minimum tier for alerts (any tier in the ladder, or never_contacted)")
+	alertStatePath := flag.String("alert-state", "", "Optional JSON state file; limits the alerts CSV to scholars newly at or escalated past --min-tier")
+	alertCooldown := flag.Int("alert-cooldown", 0, "With --alert-state, days before the same scholar can be alerted again")
+	dbEnabled := flag.Bool("db", false, "Store report in Postgres or a sqlite:// file (requires TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL; history, export, compare, and migrate read Postgres only)")
 	dbSchema := flag.String("db-schema", "touchpoint_gap_audit", "Postgres schema for audit tables")
 	dbTag := flag.String("db-tag", "", "Optional label for this audit run")
+	dbTimeout := flag.Duration("db-timeout", defaultDBTimeout, "Timeout for storing a run in the database (e.g. 30s, 5m)")
+	dbMode := flag.String("db-mode", storeModeAppend, "When a run with the same input fingerprint exists: append, skip, or replace")
 	initDB := flag.Bool("init-db", false, "Initialize database schema and seed data if empty")
+	replayFrom := flag.String("replay-from", "", "Replay snapshots starting at this as-of date (YYYY-MM-DD)")
+	replayTo := flag.String("replay-to", "", "Last replay as-of date (YYYY-MM-DD); default --as-of or today")
+	replayStep := flag.String("replay-step", "weekly", "Replay step: daily, weekly, monthly, or a count like 10d, 2w, 3m")
+	replayCSV := flag.String("replay-csv", "", "Optional CSV output of replay summary time series")
+	replayProgramsCSV := flag.String("replay-programs-csv", "", "Optional CSV output of replay per-program tier counts")
+	replayJSON := flag.String("replay-json", "", "Optional JSON output of replay time series")
 	flag.Parse()
 
-	if *inputPath == "" {
+	if len(inputs) == 0 {
 		exitWithError(errors.New("--input is required"))
 	}
+	inputPaths, err := expandInputs(inputs)
+	if err != nil {
+		exitWithError(err)
+	}
 	if *cadenceDays <= 0 {
 		exitWithError(errors.New("--cadence must be positive"))
 	}
+	switch *dbMode {
+	case storeModeAppend, storeModeSkip, storeModeReplace:
+	default:
+		exitWithError(fmt.Errorf("invalid --db-mode value: %s (use append, skip, or replace)", *dbMode))
+	}
+	if *snoozeMode != snoozeModeSuppress && *snoozeMode != snoozeModeMark {
+		exitWithError(fmt.Errorf("invalid --snooze-mode value: %s (use suppress or mark)", *snoozeMode))
+	}
+	if *holidaysPath != "" && !*businessDays {
+		exitWithError(errors.New("--holidays requires --business-days"))
+	}
+	if *alertCooldown < 0 {
+		exitWithError(errors.New("--alert-cooldown must not be negative"))
+	}
+	if *alertStatePath != "" && *alertsOut == "" {
+		exitWithError(errors.New("--alert-state requires --alerts; the state only records scholars written to the alerts CSV"))
+	}
+	if *alertStatePath != "" && *replayFrom != "" {
+		exitWithError(errors.New("--alert-state cannot be combined with --replay-from"))
+	}
+	if *scheduleFuture && *replayFrom != "" {
+		exitWithError(errors.New("--schedule-future cannot be combined with --replay-from; replay snapshots only see touchpoints on or before each as-of date"))
+	}
+
+	var location *time.Location
+	if *timezone != "" {
+		loaded, err := time.LoadLocation(*timezone)
+		if err != nil {
+			exitWithError(fmt.Errorf("invalid --timezone: %w", err))
+		}
+		location = loaded
+	}
 
 	asOfDate := time.Now()
+	if location != nil {
+		asOfDate = asOfDate.In(location)
+	}
 	if *asOf != "" {
-		parsed, err := parseDate(*asOf)
+		parsed, err := parseDateIn(*asOf, location)
 		if err != nil {
 			exitWithError(fmt.Errorf("invalid --as-of date: %w", err))
 		}
@@ -157,58 +718,231 @@ func main() {
 
 	dueWindowDays := *dueWindow
 	if dueWindowDays <= 0 {
-		dueWindowDays = int(math.Ceil(float64(*cadenceDays) * 0.5))
+		dueWindowDays = defaultDueWindow(*cadenceDays)
 	}
 
-	report, err := buildReport(*inputPath, asOfDate, *cadenceDays, dueWindowDays, *topN, *dedupeDay)
-	if err != nil {
-		exitWithError(err)
+	var policy *CadencePolicy
+	if *policyPath != "" {
+		loaded, err := loadCadencePolicy(*policyPath, CadenceRule{CadenceDays: *cadenceDays, DueWindowDays: dueWindowDays})
+		if err != nil {
+			exitWithError(err)
+		}
+		policy = loaded
+	}
+
+	ladder := defaultTierLadder()
+	if *tiersPath != "" {
+		loaded, err := loadTierLadder(*tiersPath)
+		if err != nil {
+			exitWithError(err)
+		}
+		ladder = loaded
+	}
+	minTierNames := ladder.names()
+	if *scheduleFuture {
+		minTierNames = withScheduledTier(minTierNames)
+	}
+	if _, ok := tierRank(minTierNames, *minTier); !ok {
+		exitWithError(fmt.Errorf("invalid --min-tier value: %s", *minTier))
 	}
 
-	printReport(report, *inputPath)
+	var columns *ColumnMapping
+	if *columnsPath != "" {
+		loaded, err := loadColumnMapping(*columnsPath)
+		if err != nil {
+			exitWithError(err)
+		}
+		columns = loaded
+	}
 
-	if *jsonOut != "" {
-		if err := writeJSON(report, *jsonOut); err != nil {
+	var roster map[string]RosterEntry
+	if *rosterPath != "" {
+		loaded, err := loadRoster(*rosterPath, location)
+		if err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("\nJSON report saved to %s\n", *jsonOut)
+		roster = loaded
 	}
 
-	if *alertsOut != "" {
-		if err := writeAlertsCSV(report, *alertsOut, *minTier); err != nil {
+	var acks map[string]Ack
+	if *acksPath != "" {
+		loaded, err := loadAcks(*acksPath, location)
+		if err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Alert CSV saved to %s\n", *alertsOut)
+		acks = loaded
 	}
-	if *programsOut != "" {
-		if err := writeProgramCSV(report, *programsOut); err != nil {
+
+	var exclusions map[string][]Exclusion
+	if *exclusionsPath != "" {
+		loaded, err := loadExclusions(*exclusionsPath, location)
+		if err != nil {
+			exitWithError(err)
+		}
+		exclusions = loaded
+	}
+
+	var holidays []Holiday
+	if *holidaysPath != "" {
+		loaded, err := loadHolidays(*holidaysPath, location)
+		if err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Program summary CSV saved to %s\n", *programsOut)
+		holidays = loaded
 	}
-	if *channelsOut != "" {
-		if err := writeChannelCSV(report, *channelsOut); err != nil {
+
+	var calendar BlackoutCalendar
+	if *calendarPath != "" {
+		loaded, err := loadCalendar(*calendarPath, location)
+		if err != nil {
+			exitWithError(err)
+		}
+		calendar = loaded
+	}
+
+	opts := AuditOptions{
+		AsOf:            asOfDate,
+		CadenceDays:     *cadenceDays,
+		DueWindowDays:   dueWindowDays,
+		TopN:            *topN,
+		MinTier:         *minTier,
+		DedupeDay:       *dedupeDay,
+		Roster:          roster,
+		Policy:          policy,
+		Ladder:          ladder,
+		Columns:         columns,
+		Location:        location,
+		ScheduleFuture:  *scheduleFuture,
+		SuccessStatuses: parseStatusList(*successStatuses),
+		Acks:            acks,
+		SnoozeMode:      *snoozeMode,
+		Exclusions:      exclusions,
+		Calendar:        calendar,
+		BusinessDays:    *businessDays,
+		Holidays:        holidays,
+	}
+
+	if *replayFrom != "" {
+		start, err := parseDateIn(*replayFrom, location)
+		if err != nil {
+			exitWithError(fmt.Errorf("invalid --replay-from date: %w", err))
+		}
+		end := asOfDate
+		if *replayTo != "" {
+			parsed, err := parseDateIn(*replayTo, location)
+			if err != nil {
+				exitWithError(fmt.Errorf("invalid --replay-to date: %w", err))
+			}
+			end = dateOnly(parsed)
+		}
+		step, err := parseReplayStep(*replayStep)
+		if err != nil {
+			exitWithError(err)
+		}
+		replay, err := buildReplay(inputPaths, opts, dateOnly(start), end, step)
+		if err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Channel summary CSV saved to %s\n", *channelsOut)
+		printReplay(replay)
+		if *replayJSON != "" {
+			if err := writeReplayJSON(replay, *replayJSON); err != nil {
+				exitWithError(err)
+			}
+			fmt.Printf("\nReplay JSON saved to %s\n", *replayJSON)
+		}
+		if *replayCSV != "" {
+			if err := writeReplayCSV(replay, *replayCSV); err != nil {
+				exitWithError(err)
+			}
+			fmt.Printf("Replay summary CSV saved to %s\n", *replayCSV)
+		}
+		if *replayProgramsCSV != "" {
+			if err := writeReplayProgramsCSV(replay, *replayProgramsCSV); err != nil {
+				exitWithError(err)
+			}
+			fmt.Printf("Replay program CSV saved to %s\n", *replayProgramsCSV)
+		}
+		if *dbEnabled {
+			dbURL := dbURLFromEnv()
+			if dbURL == "" {
+				exitWithError(errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL"))
+			}
+			stored, err := storeReplayInDB(replay, DBConfig{URL: dbURL, Schema: *dbSchema, Tag: *dbTag, Timeout: *dbTimeout, Mode: *dbMode})
+			if err != nil {
+				exitWithError(err)
+			}
+			skipped := 0
+			for _, run := range stored {
+				if run.Skipped {
+					skipped++
+				}
+			}
+			fmt.Printf("\nStored %d replay snapshots in %s (%d skipped as already stored)\n", len(stored)-skipped, dbBackendName(dbURL), skipped)
+		}
+		return
+	}
+
+	report, err := buildReport(inputPaths, opts)
+	if err != nil {
+		exitWithError(err)
 	}
-	if *statusesOut != "" {
-		if err := writeStatusCSV(report, *statusesOut); err != nil {
+
+	if *rejectsOut != "" {
+		if err := writeRejectsCSV(report, *rejectsOut); err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Status summary CSV saved to %s\n", *statusesOut)
 	}
-	if *dueOut != "" {
-		if err := writeDueCSV(report, *dueOut); err != nil {
+	if *strict && report.Summary.RejectRate > *maxRejectRate {
+		exitWithError(fmt.Errorf("reject rate %.1f%% exceeds --max-reject-rate %.1f%% (%d of %d rows rejected)",
+			report.Summary.RejectRate*100, *maxRejectRate*100, report.Summary.RejectedRows, report.Summary.InputRows))
+	}
+
+	printReport(report)
+
+	var alertReasons map[string]string
+	var alertState AlertState
+	if *alertStatePath != "" {
+		previous, err := loadAlertState(*alertStatePath)
+		if err != nil {
+			exitWithError(err)
+		}
+		reasons, changes, next, err := planAlerts(report, previous, *minTier, *alertCooldown)
+		if err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Due summary CSV saved to %s\n", *dueOut)
+		alertReasons, alertState = reasons, next
+		report.Summary.AlertChanges = &changes
+		fmt.Printf("\nAlert changes: new %d | escalated %d | unchanged %d | cooldown %d\n",
+			changes.New, changes.Escalated, changes.Unchanged, changes.Cooldown)
+	}
+
+	// Export before storing so a database failure cannot cost the run its
+	// files. A successful store rewrites them with tier_since and
+	// days_in_tier from the stored tier history.
+	exports := ExportPaths{
+		JSON:         *jsonOut,
+		Alerts:       *alertsOut,
+		MinTier:      *minTier,
+		Programs:     *programsOut,
+		Owners:       *ownersOut,
+		Channels:     *channelsOut,
+		Statuses:     *statusesOut,
+		Due:          *dueOut,
+		Recency:      *recencyOut,
+		AlertReasons: alertReasons,
+	}
+	if err := writeExports(report, exports); err != nil {
+		exitWithError(err)
+	}
+	if *rejectsOut != "" {
+		fmt.Printf("Rejected rows CSV saved to %s\n", *rejectsOut)
 	}
-	if *recencyOut != "" {
-		if err := writeRecencyCSV(report, *recencyOut); err != nil {
+	// Save state only after the exports land so a failed run re-alerts.
+	if *alertStatePath != "" {
+		if err := writeAlertState(alertState, *alertStatePath); err != nil {
 			exitWithError(err)
 		}
-		fmt.Printf("Recency summary CSV saved to %s\n", *recencyOut)
+		fmt.Printf("Alert state saved to %s\n", *alertStatePath)
 	}
 
 	if *dbEnabled || *initDB {
@@ -217,176 +951,190 @@ func main() {
 			exitWithError(errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL"))
 		}
 		cfg := DBConfig{
-			URL:    dbURL,
-			Schema: *dbSchema,
-			Tag:    *dbTag,
+			URL:      dbURL,
+			Schema:   *dbSchema,
+			Tag:      *dbTag,
+			Timeout:  *dbTimeout,
+			Progress: os.Stdout,
+			Mode:     *dbMode,
 		}
+		var tierSince map[string]time.Time
 		seeded := false
 		if *initDB {
-			runID, err := seedDatabase(report, cfg)
+			stored, err := seedDatabase(report, cfg)
 			if err != nil {
 				exitWithError(err)
 			}
-			if runID != "" {
+			if stored.RunID != "" {
 				seeded = true
-				fmt.Printf("\nSeeded Postgres with initial audit run (run_id=%s)\n", runID)
+				tierSince = stored.TierSince
+				fmt.Printf("\nSeeded %s with initial audit run (run_id=%s)\n", dbBackendName(dbURL), stored.RunID)
 			}
 		}
 		if *dbEnabled {
 			if seeded {
 				fmt.Println("Skipped duplicate insert; current report already used for seed.")
 			} else {
-				runID, err := storeReportInDB(report, cfg)
+				stored, err := storeReportInDB(report, cfg)
 				if err != nil {
 					exitWithError(err)
 				}
-				fmt.Printf("\nStored audit run in Postgres (run_id=%s)\n", runID)
+				tierSince = stored.TierSince
+				switch {
+				case stored.Skipped:
+					fmt.Printf("\nSkipped store; run with the same input fingerprint already exists (run_id=%s)\n", stored.RunID)
+				case stored.Replaced > 0:
+					fmt.Printf("\nStored audit run in %s (run_id=%s), replacing %d run(s) with the same input fingerprint\n", dbBackendName(dbURL), stored.RunID, stored.Replaced)
+				default:
+					fmt.Printf("\nStored audit run in %s (run_id=%s)\n", dbBackendName(dbURL), stored.RunID)
+				}
+			}
+		}
+		if len(tierSince) > 0 {
+			if err := writeExports(withTierSince(report, tierSince), exports); err != nil {
+				exitWithError(err)
 			}
 		}
 	}
 }
 
-func buildReport(path string, asOf time.Time, cadenceDays int, dueWindowDays int, topN int, dedupeDay bool) (Report, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return Report{}, err
-	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.TrimLeadingSpace = true
-	reader.FieldsPerRecord = -1
-
-	headers, err := reader.Read()
-	if err != nil {
-		return Report{}, fmt.Errorf("unable to read header: %w", err)
-	}
-
-	colMap := normalizeHeaders(headers)
-	idIdx, ok := findColumn(colMap, []string{"scholar_id", "scholarid", "scholar", "student_id", "studentid"})
-	if !ok {
-		return Report{}, errors.New("missing scholar_id column")
+func buildReport(paths []string, opts AuditOptions) (Report, error) {
+	asOf := opts.AsOf
+	topN := opts.TopN
+	ladder := opts.Ladder
+	if ladder == nil {
+		ladder = defaultTierLadder()
 	}
-	dateIdx, ok := findColumn(colMap, []string{"contact_date", "contacted_at", "date", "touchpoint_date", "touchpoint"})
-	if !ok {
-		return Report{}, errors.New("missing contact_date column")
+	tierNames := ladder.names()
+	if opts.ScheduleFuture {
+		tierNames = withScheduledTier(tierNames)
 	}
-	programIdx, _ := findColumn(colMap, []string{"program", "cohort", "track"})
-	channelIdx, _ := findColumn(colMap, []string{"channel", "method", "touchpoint_channel"})
-	statusIdx, _ := findColumn(colMap, []string{"status", "outcome", "result"})
 
-	stats := map[string]*ScholarStats{}
-	invalidRows := 0
-	futureRows := 0
+	extraFields := opts.Columns.passthroughNames()
 	asOfDate := dateOnly(asOf)
-
-	for {
-		record, err := reader.Read()
+	loader := &touchpointLoader{
+		opts:          opts,
+		asOfDate:      asOfDate,
+		stats:         map[string]*ScholarStats{},
+		rejectReasons: map[string]int{},
+		bookings:      map[string]booking{},
+	}
+	sources := make([]SourceSummary, 0, len(paths))
+	for _, path := range paths {
+		source, err := loader.readFile(path)
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
+			return Report{}, fmt.Errorf("%s: %w", path, err)
+		}
+		sources = append(sources, source)
+	}
+	stats := loader.stats
+	rejects := loader.rejects
+
+	rosterScholars := 0
+	for scholarID, entry := range opts.Roster {
+		scholar, exists := stats[scholarID]
+		if !exists {
+			if !entry.EnrollmentDate.IsZero() && dateOnly(entry.EnrollmentDate).After(asOfDate) {
+				continue
 			}
-			return Report{}, fmt.Errorf("unable to read CSV: %w", err)
+			scholar = &ScholarStats{ScholarID: scholarID, Channels: map[string]int{}}
+			stats[scholarID] = scholar
 		}
-		if len(record) == 0 {
-			continue
+		rosterScholars++
+		if entry.Program != "" {
+			scholar.Program = entry.Program
 		}
-
-		scholarID := getValue(record, idIdx)
-		if scholarID == "" {
-			invalidRows++
+		if entry.Owner != "" {
+			scholar.Owner = entry.Owner
+		}
+		scholar.Enrolled = entry.EnrollmentDate
+	}
+	for scholarID, pending := range loader.bookings {
+		scholar, exists := stats[scholarID]
+		if !exists {
 			continue
 		}
+		if pending.Program != "" && scholar.Program == "" {
+			scholar.Program = pending.Program
+		}
+		scholar.Scheduled = pending.Date
+	}
 
-		dateStr := getValue(record, dateIdx)
-		parsedDate, err := parseDate(dateStr)
-		if err != nil {
-			invalidRows++
+	summaries := make([]ScholarSummary, 0, len(stats))
+	gapValues := make([]int, 0, len(stats))
+	failedAttemptsTotal := 0
+	missedCadencesTotal := 0
+	maxMissedCadences := 0
+	channelSummary := map[string]int{}
+	statusSummary := map[string]int{}
+	programBuckets := map[string][]ScholarSummary{}
+	ownerBuckets := map[string][]ScholarSummary{}
+	ownersSeen := false
+	snoozed := []ScholarSummary{}
+	snoozedCount := 0
+	snoozeReasons := map[string]int{}
+	excluded := []ExcludedScholar{}
+	excludedReasons := map[string]int{}
+	workdays := opts.workdays()
+
+	for _, scholar := range stats {
+		exclusion, active, pauses := exclusionStatus(opts.Exclusions[scholar.ScholarID], asOfDate)
+		if active {
+			excluded = append(excluded, ExcludedScholar{
+				ScholarID: scholar.ScholarID,
+				Program:   scholar.Program,
+				Owner:     scholar.Owner,
+				Reason:    exclusion.Reason,
+				Start:     exclusion.Start,
+				End:       exclusion.End,
+			})
+			reason := exclusion.Reason
+			if reason == "" {
+				reason = "Unspecified"
+			}
+			excludedReasons[reason]++
 			continue
 		}
-		if parsedDate.After(asOfDate) {
-			futureRows++
-			continue
+		rule := opts.cadenceFor(scholar.Program)
+		cadenceDays := rule.CadenceDays
+		dueWindowDays := rule.DueWindowDays
+		// The cadence clock runs from the last successful contact; scholars
+		// with only failed attempts fall back to enrollment, then first attempt.
+		clockStart := scholar.LastSuccess
+		if clockStart.IsZero() {
+			clockStart = scholar.Enrolled
 		}
-
-		program := ""
-		if programIdx >= 0 {
-			program = getValue(record, programIdx)
+		if clockStart.IsZero() {
+			clockStart = scholar.FirstContact
 		}
-		channel := ""
-		if channelIdx >= 0 {
-			channel = getValue(record, channelIdx)
-		}
-		status := ""
-		if statusIdx >= 0 {
-			status = getValue(record, statusIdx)
-		}
-
-		scholar, exists := stats[scholarID]
-		if !exists {
-			scholar = &ScholarStats{ScholarID: scholarID, Channels: map[string]int{}}
-			stats[scholarID] = scholar
-		}
-		if program != "" && scholar.Program == "" {
-			scholar.Program = program
-		}
-		if dedupeDay {
-			if scholar.ContactDates == nil {
-				scholar.ContactDates = map[string]struct{}{}
-			}
-			dateKey := dateOnly(parsedDate).Format("2006-01-02")
-			if _, seen := scholar.ContactDates[dateKey]; seen {
-				if scholar.LastContact.IsZero() || parsedDate.After(scholar.LastContact) || parsedDate.Equal(scholar.LastContact) {
-					scholar.LastContact = parsedDate
-					scholar.LastChannel = channel
-					scholar.LastStatus = status
-				}
-				continue
-			}
-			scholar.ContactDates[dateKey] = struct{}{}
-		}
-		scholar.ContactCount++
-		scholar.Contacts = append(scholar.Contacts, parsedDate)
-		if !scholar.FirstContact.IsZero() {
-			if parsedDate.Before(scholar.FirstContact) {
-				scholar.FirstContact = parsedDate
-			}
-		} else {
-			scholar.FirstContact = parsedDate
-		}
-		if channel != "" {
-			scholar.Channels[channel]++
-		}
-		if scholar.LastContact.IsZero() || parsedDate.After(scholar.LastContact) {
-			scholar.LastContact = parsedDate
-			scholar.LastChannel = channel
-			scholar.LastStatus = status
-		}
-	}
-
-	summaries := make([]ScholarSummary, 0, len(stats))
-	gapValues := make([]int, 0, len(stats))
-	missedCadencesTotal := 0
-	maxMissedCadences := 0
-	channelSummary := map[string]int{}
-	statusSummary := map[string]int{}
-	programBuckets := map[string][]ScholarSummary{}
-
-	for _, scholar := range stats {
-		gap := gapDays(asOf, scholar.LastContact)
+		// Ended exclusions and calendar blackouts stop the clock: their days
+		// count toward neither the gap nor the next due date. In business-day
+		// mode weekends and holidays never count either.
+		clock := workdays.withPauses(append(pauses, opts.Calendar.windowsFor(scholar.Program)...))
+		rawGap := gapDays(asOf, clockStart)
+		gap, paused := clock.elapsed(clockStart, asOfDate)
 		missedCadencesValue := missedCadences(gap, cadenceDays)
-		tier := gapTier(gap, cadenceDays, dueWindowDays)
+		tier := ladder.classify(gap, cadenceDays, dueWindowDays)
 		nextDueDate := time.Time{}
 		daysPastDue := 0
 		daysSinceFirst := 0
 		avgInterval := 0.0
 		contactsPerMonthRate := 0.0
-		if !scholar.LastContact.IsZero() {
-			nextDueDate = dateOnly(scholar.LastContact.AddDate(0, 0, cadenceDays))
+		if scholar.LastContact.IsZero() {
+			// Never-contacted scholars accrue their gap from enrollment.
+			tier = tierNeverContacted
+		}
+		failed, sinceSuccess, streak := summarizeAttempts(scholar.Attempts)
+		failedAttemptsTotal += failed
+		if !clockStart.IsZero() {
+			nextDueDate = clock.advance(clockStart, cadenceDays)
 			if gap > cadenceDays {
 				daysPastDue = gap - cadenceDays
 			}
 		}
+		if isScheduled(tier, ladder, scholar.Scheduled, nextDueDate, asOfDate, workdays.advance(asOfDate, dueWindowDays)) {
+			tier = tierScheduled
+		}
 		if !scholar.FirstContact.IsZero() {
 			daysSinceFirst = gapDays(asOf, scholar.FirstContact)
 			avgInterval = averageIntervalDays(scholar.Contacts)
@@ -395,19 +1143,48 @@ func buildReport(path string, asOf time.Time, cadenceDays int, dueWindowDays int
 		summary := ScholarSummary{
 			ScholarID:        scholar.ScholarID,
 			Program:          scholar.Program,
+			Owner:            scholar.Owner,
+			EnrollmentDate:   scholar.Enrolled,
 			LastChannel:      scholar.LastChannel,
 			LastStatus:       scholar.LastStatus,
+			LastSource:       scholar.LastSource,
 			LastContact:      scholar.LastContact,
+			LastSuccess:      scholar.LastSuccess,
 			FirstContact:     scholar.FirstContact,
 			NextDueDate:      nextDueDate,
+			ScheduledDate:    scholar.Scheduled,
 			ContactCount:     scholar.ContactCount,
+			FailedAttempts:   failed,
+			SinceSuccess:     sinceSuccess,
+			FailedStreak:     streak,
 			GapDays:          gap,
+			RawGapDays:       rawGap,
 			DaysPastDue:      daysPastDue,
 			MissedCadences:   missedCadencesValue,
+			CadenceDays:      cadenceDays,
+			DueWindowDays:    dueWindowDays,
 			DaysSinceFirst:   daysSinceFirst,
 			AvgIntervalDays:  avgInterval,
 			ContactsPerMonth: contactsPerMonthRate,
 			Tier:             tier,
+			PausedDays:       paused,
+			Extra:            scholar.Extra,
+		}
+		if ack, ok := opts.activeAck(scholar.ScholarID, asOfDate); ok {
+			summary.SnoozedUntil = ack.SnoozeUntil
+			summary.SnoozeReason = ack.Reason
+			summary.AckedBy = ack.AckedBy
+			reason := ack.Reason
+			if reason == "" {
+				reason = "Unspecified"
+			}
+			snoozeReasons[reason]++
+			snoozedCount++
+			if opts.snoozeMode() == snoozeModeSuppress {
+				// Suppressed scholars sit outside every count and rollup.
+				snoozed = append(snoozed, summary)
+				continue
+			}
 		}
 		summaries = append(summaries, summary)
 		gapValues = append(gapValues, gap)
@@ -428,82 +1205,441 @@ func buildReport(path string, asOf time.Time, cadenceDays int, dueWindowDays int
 			programKey = "Unassigned"
 		}
 		programBuckets[programKey] = append(programBuckets[programKey], summary)
+		ownerKey := summary.Owner
+		if ownerKey == "" {
+			ownerKey = "Unassigned"
+		} else {
+			ownersSeen = true
+		}
+		ownerBuckets[ownerKey] = append(ownerBuckets[ownerKey], summary)
 	}
 
 	sort.Slice(summaries, func(i, j int) bool {
 		return summaries[i].GapDays > summaries[j].GapDays
 	})
+	sort.Slice(snoozed, func(i, j int) bool {
+		return snoozed[i].GapDays > snoozed[j].GapDays
+	})
+	sort.Slice(excluded, func(i, j int) bool {
+		return excluded[i].ScholarID < excluded[j].ScholarID
+	})
 
 	topGaps := summaries
 	if topN > 0 && len(topGaps) > topN {
 		topGaps = topGaps[:topN]
 	}
 
-	programSummary := buildProgramSummary(programBuckets)
+	programSummary := buildProgramSummary(programBuckets, tierNames)
 	if len(programSummary) > 1 {
-		sort.Slice(programSummary, func(i, j int) bool {
-			return programSummary[i].OverdueCount+programSummary[i].CriticalCount > programSummary[j].OverdueCount+programSummary[j].CriticalCount
+		sort.SliceStable(programSummary, func(i, j int) bool {
+			return moreSevere(programSummary[i].TierCounts, programSummary[j].TierCounts)
 		})
 	}
 
+	var ownerSummary []OwnerSummary
+	if ownersSeen {
+		ownerSummary = buildOwnerSummary(ownerBuckets, tierNames)
+	}
+
+	fingerprint, err := auditFingerprint(sources, opts)
+	if err != nil {
+		return Report{}, err
+	}
+
 	avgGap, medianGap, maxGap := summarizeGaps(gapValues)
 	avgMissedCadences := 0.0
 	if len(summaries) > 0 {
 		avgMissedCadences = round1(float64(missedCadencesTotal) / float64(len(summaries)))
 	}
 
-	onTrack, dueSoon, overdue, critical := countTiers(summaries)
+	tierCounts := countTiers(summaries, tierNames)
+	defaultRule := opts.cadenceFor("")
 
 	report := Report{
+		Tiers:       tierNames,
+		ExtraFields: extraFields,
 		Summary: ReportSummary{
 			AsOf:              asOf.Format("2006-01-02"),
-			CadenceDays:       cadenceDays,
-			DueWindowDays:     dueWindowDays,
+			Timezone:          locationName(opts.Location),
+			CadenceDays:       defaultRule.CadenceDays,
+			DueWindowDays:     defaultRule.DueWindowDays,
+			PolicyPrograms:    opts.Policy.programCount(),
 			TotalScholars:     len(summaries),
 			AvgGapDays:        avgGap,
 			MedianGapDays:     medianGap,
 			MaxGapDays:        maxGap,
 			AvgMissedCadences: avgMissedCadences,
 			MaxMissedCadences: maxMissedCadences,
-			OnTrackCount:      onTrack,
-			DueSoonCount:      dueSoon,
-			OverdueCount:      overdue,
-			CriticalCount:     critical,
-			InvalidRows:       invalidRows,
-			FutureRows:        futureRows,
+			TierCounts:        tierCounts,
+			RosterScholars:    rosterScholars,
+			SuccessStatuses:   sortedKeys(opts.SuccessStatuses),
+			FailedAttempts:    failedAttemptsTotal,
+			InputRows:         loader.inputRows,
+			InvalidRows:       loader.invalidRows,
+			FutureRows:        loader.futureRows,
+			ScheduleFuture:    opts.ScheduleFuture,
+			RejectedRows:      loader.rejectedRows,
+			RejectRate:        rejectRate(loader.rejectedRows, loader.inputRows),
+			RejectReasons:     loader.rejectReasons,
+			DuplicateRows:     loader.duplicateRows,
+			InputFingerprint:  fingerprint,
+			DedupeDay:         opts.DedupeDay,
+			TopN:              topN,
+			MinTier:           opts.MinTier,
+			ToolVersion:       toolVersion(),
+			SnoozeMode:        opts.snoozeMode(),
+			SnoozedScholars:   snoozedCount,
+			SnoozeReasons:     snoozeReasons,
+			ExcludedScholars:  len(excluded),
+			ExcludedReasons:   excludedReasons,
+			BlackoutPeriods:   len(opts.Calendar),
+			BusinessDays:      opts.BusinessDays,
+		},
+		Parameters: RunParameters{
+			Policy:   opts.Policy,
+			Ladder:   ladder,
+			Columns:  opts.Columns,
+			Calendar: opts.Calendar,
+			Holidays: opts.Holidays,
 		},
+		Sources:        sources,
 		ProgramSummary: programSummary,
+		OwnerSummary:   ownerSummary,
 		ChannelSummary: channelSummary,
 		StatusSummary:  statusSummary,
-		DueSummary:     buildDueSummary(summaries, asOfDate),
+		DueSummary:     buildDueSummary(summaries, asOfDate, workdays),
 		RecencySummary: buildRecencySummary(summaries),
 		TopGaps:        topGaps,
 		Scholars:       summaries,
+		Snoozed:        snoozed,
+		Excluded:       excluded,
+		Rejects:        rejects,
 	}
 
 	return report, nil
 }
 
-func buildProgramSummary(buckets map[string][]ScholarSummary) []ProgramSummary {
+// touchpointLoader accumulates scholar stats and row accounting across one or
+// more touchpoint files.
+type touchpointLoader struct {
+	opts          AuditOptions
+	asOfDate      time.Time
+	stats         map[string]*ScholarStats
+	inputRows     int
+	invalidRows   int
+	futureRows    int
+	rejects       []RejectedRow
+	rejectReasons map[string]int
+	rejectedRows  int
+	duplicateRows int
+	// bookings holds each scholar's earliest future touchpoint under
+	// ScheduleFuture until every file and the roster are loaded, so a
+	// booking alone never creates a scholar.
+	bookings map[string]booking
+}
+
+type booking struct {
+	Date    time.Time
+	Program string
+}
+
+func (loader *touchpointLoader) scholar(scholarID string) *ScholarStats {
+	scholar, exists := loader.stats[scholarID]
+	if !exists {
+		scholar = &ScholarStats{ScholarID: scholarID, Channels: map[string]int{}}
+		loader.stats[scholarID] = scholar
+	}
+	return scholar
+}
+
+func (loader *touchpointLoader) readFile(path string) (SourceSummary, error) {
+	opts := loader.opts
+	source := SourceSummary{Path: path}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return source, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	reader := csv.NewReader(io.TeeReader(file, hash))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return source, fmt.Errorf("unable to read header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	fieldIdx := map[string]int{}
+	for _, field := range touchpointFieldOrder {
+		idx, err := opts.Columns.resolve(colMap, field)
+		if err != nil {
+			return source, err
+		}
+		fieldIdx[field] = idx
+	}
+	idIdx := fieldIdx["scholar_id"]
+	if idIdx < 0 {
+		return source, errors.New("missing scholar_id column")
+	}
+	dateIdx := fieldIdx["contact_date"]
+	if dateIdx < 0 {
+		return source, errors.New("missing contact_date column")
+	}
+	programIdx := fieldIdx["program"]
+	channelIdx := fieldIdx["channel"]
+	statusIdx := fieldIdx["status"]
+	ownerIdx := fieldIdx["owner"]
+	extraFields := opts.Columns.passthroughNames()
+	extraIdx := map[string]int{}
+	for _, name := range extraFields {
+		idx, ok := findColumn(colMap, []string{opts.Columns.Passthrough[name]})
+		if !ok {
+			return source, fmt.Errorf("passthrough column %q not found for %s", opts.Columns.Passthrough[name], name)
+		}
+		extraIdx[name] = idx
+	}
+
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return source, fmt.Errorf("unable to read CSV: %w", err)
+		}
+		if len(record) == 0 {
+			continue
+		}
+		loader.inputRows++
+		source.Rows++
+		line, _ := reader.FieldPos(0)
+		// listRow records a row in the rejects CSV; reject also counts it
+		// toward the reject rate.
+		listRow := func(reason string) {
+			loader.rejects = append(loader.rejects, RejectedRow{
+				Source:      path,
+				Line:        line,
+				Reason:      reason,
+				ScholarID:   getValue(record, idIdx),
+				ContactDate: getValue(record, dateIdx),
+				Raw:         append([]string{}, record...),
+			})
+		}
+		reject := func(reason string) {
+			loader.rejectReasons[reason]++
+			loader.rejectedRows++
+			source.RejectedRows++
+			listRow(reason)
+		}
+
+		scholarID := getValue(record, idIdx)
+		if scholarID == "" {
+			loader.invalidRows++
+			source.InvalidRows++
+			reject(rejectMissingID)
+			continue
+		}
+
+		dateStr := getValue(record, dateIdx)
+		parsedDate, err := parseDateIn(dateStr, opts.Location)
+		if err != nil {
+			loader.invalidRows++
+			source.InvalidRows++
+			reject(rejectUnparseableDate)
+			continue
+		}
+		if dateOnly(parsedDate).After(loader.asOfDate) {
+			loader.futureRows++
+			source.FutureRows++
+			// Replay snapshots must never see later history, even as
+			// bookings, so SkipFuture wins over ScheduleFuture.
+			if opts.SkipFuture {
+				continue
+			}
+			if !opts.ScheduleFuture {
+				reject(rejectFutureDate)
+				continue
+			}
+			pending := loader.bookings[scholarID]
+			if program := getValue(record, programIdx); program != "" && pending.Program == "" {
+				pending.Program = program
+			}
+			if pending.Date.IsZero() || parsedDate.Before(pending.Date) {
+				pending.Date = parsedDate
+			}
+			loader.bookings[scholarID] = pending
+			source.ScheduledRows++
+			continue
+		}
+
+		program := ""
+		if programIdx >= 0 {
+			program = getValue(record, programIdx)
+		}
+		channel := ""
+		if channelIdx >= 0 {
+			channel = getValue(record, channelIdx)
+		}
+		status := ""
+		if statusIdx >= 0 {
+			status = getValue(record, statusIdx)
+		}
+
+		scholar := loader.scholar(scholarID)
+		if program != "" && scholar.Program == "" {
+			scholar.Program = program
+		}
+		if owner := getValue(record, ownerIdx); owner != "" && !parsedDate.Before(scholar.OwnerSeen) {
+			scholar.Owner = owner
+			scholar.OwnerSeen = parsedDate
+		}
+		for name, idx := range extraIdx {
+			value := getValue(record, idx)
+			if value == "" {
+				continue
+			}
+			if scholar.Extra == nil {
+				scholar.Extra = map[string]string{}
+				scholar.ExtraSeen = map[string]time.Time{}
+			}
+			if !parsedDate.Before(scholar.ExtraSeen[name]) {
+				scholar.Extra[name] = value
+				scholar.ExtraSeen[name] = parsedDate
+			}
+		}
+		if opts.DedupeDay {
+			if scholar.ContactDates == nil {
+				scholar.ContactDates = map[string]struct{}{}
+			}
+			dateKey := dateOnly(parsedDate).Format("2006-01-02")
+			if _, seen := scholar.ContactDates[dateKey]; seen {
+				if scholar.LastContact.IsZero() || parsedDate.After(scholar.LastContact) || parsedDate.Equal(scholar.LastContact) {
+					scholar.LastContact = parsedDate
+					scholar.LastChannel = channel
+					scholar.LastStatus = status
+					scholar.LastSource = path
+				}
+				if opts.isSuccess(status) {
+					scholar.markSuccess(parsedDate)
+				}
+				// Merging same-day duplicates is what --dedupe-day asks
+				// for, so they are listed but not counted as rejects.
+				loader.duplicateRows++
+				source.DuplicateRows++
+				listRow(rejectDuplicateDay)
+				continue
+			}
+			scholar.ContactDates[dateKey] = struct{}{}
+		}
+		scholar.ContactCount++
+		scholar.Contacts = append(scholar.Contacts, parsedDate)
+		success := opts.isSuccess(status)
+		scholar.Attempts = append(scholar.Attempts, contactAttempt{Date: parsedDate, Success: success})
+		if success && (scholar.LastSuccess.IsZero() || parsedDate.After(scholar.LastSuccess)) {
+			scholar.LastSuccess = parsedDate
+		}
+		if !scholar.FirstContact.IsZero() {
+			if parsedDate.Before(scholar.FirstContact) {
+				scholar.FirstContact = parsedDate
+			}
+		} else {
+			scholar.FirstContact = parsedDate
+		}
+		if channel != "" {
+			scholar.Channels[channel]++
+		}
+		if scholar.LastContact.IsZero() || parsedDate.After(scholar.LastContact) {
+			scholar.LastContact = parsedDate
+			scholar.LastChannel = channel
+			scholar.LastStatus = status
+			scholar.LastSource = path
+		}
+		source.AcceptedRows++
+	}
+	source.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return source, nil
+}
+
+// auditFingerprint hashes the input file contents together with every
+// option that changes stored results, so the same audit always yields the
+// same fingerprint regardless of file paths or input order. TopN is left out
+// because it only trims console and JSON output.
+func auditFingerprint(sources []SourceSummary, opts AuditOptions) (string, error) {
+	inputs := make([]string, 0, len(sources))
+	for _, source := range sources {
+		inputs = append(inputs, source.SHA256)
+	}
+	sort.Strings(inputs)
+	params := struct {
+		Inputs          []string               `json:"inputs"`
+		AsOf            string                 `json:"as_of"`
+		Timezone        string                 `json:"timezone"`
+		CadenceDays     int                    `json:"cadence_days"`
+		DueWindowDays   int                    `json:"due_window_days"`
+		DedupeDay       bool                   `json:"dedupe_day"`
+		ScheduleFuture  bool                   `json:"schedule_future"`
+		SuccessStatuses []string               `json:"success_statuses"`
+		Policy          *CadencePolicy         `json:"policy"`
+		Roster          map[string]RosterEntry `json:"roster"`
+		Ladder          *TierLadder            `json:"ladder"`
+		Columns         *ColumnMapping         `json:"columns"`
+		Acks            map[string]Ack         `json:"acks,omitempty"`
+		SnoozeMode      string                 `json:"snooze_mode,omitempty"`
+		Exclusions      map[string][]Exclusion `json:"exclusions,omitempty"`
+		Calendar        BlackoutCalendar       `json:"calendar,omitempty"`
+		BusinessDays    bool                   `json:"business_days,omitempty"`
+		Holidays        []Holiday              `json:"holidays,omitempty"`
+		TopN            int                    `json:"top_n"`
+		MinTier         string                 `json:"min_tier,omitempty"`
+	}{
+		Inputs:          inputs,
+		AsOf:            formatDate(opts.AsOf),
+		Timezone:        locationName(opts.Location),
+		CadenceDays:     opts.CadenceDays,
+		DueWindowDays:   opts.DueWindowDays,
+		DedupeDay:       opts.DedupeDay,
+		ScheduleFuture:  opts.ScheduleFuture,
+		SuccessStatuses: sortedKeys(opts.SuccessStatuses),
+		Policy:          opts.Policy,
+		Roster:          opts.Roster,
+		Ladder:          opts.Ladder,
+		Columns:         opts.Columns,
+		Acks:            opts.Acks,
+		SnoozeMode:      opts.snoozeMode(),
+		Exclusions:      opts.Exclusions,
+		Calendar:        opts.Calendar,
+		BusinessDays:    opts.BusinessDays,
+		Holidays:        opts.Holidays,
+		TopN:            opts.TopN,
+		MinTier:         opts.MinTier,
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func buildProgramSummary(buckets map[string][]ScholarSummary, tierNames []string) []ProgramSummary {
 	result := make([]ProgramSummary, 0, len(buckets))
 	for program, entries := range buckets {
 		gaps := make([]int, 0, len(entries))
 		programSummary := ProgramSummary{Program: program, Scholars: len(entries)}
+		if len(entries) > 0 {
+			programSummary.CadenceDays = entries[0].CadenceDays
+			programSummary.DueWindowDays = entries[0].DueWindowDays
+		}
 		missedTotal := 0
 		for _, entry := range entries {
 			gaps = append(gaps, entry.GapDays)
 			missedTotal += entry.MissedCadences
-			switch entry.Tier {
-			case "on_track":
-				programSummary.OnTrackCount++
-			case "due_soon":
-				programSummary.DueSoonCount++
-			case "overdue":
-				programSummary.OverdueCount++
-			case "critical":
-				programSummary.CriticalCount++
-			}
 		}
+		programSummary.TierCounts = countTiers(entries, tierNames)
 		avgGap, _, _ := summarizeGaps(gaps)
 		programSummary.AvgGapDays = avgGap
 		if programSummary.Scholars > 0 {
@@ -514,6 +1650,144 @@ func buildProgramSummary(buckets map[string][]ScholarSummary) []ProgramSummary {
 	return result
 }
 
+func (opts AuditOptions) isSuccess(status string) bool {
+	if len(opts.SuccessStatuses) == 0 {
+		return true
+	}
+	return opts.SuccessStatuses[normalizeStatus(status)]
+}
+
+func parseStatusList(value string) map[string]bool {
+	result := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		if key := normalizeStatus(part); key != "" {
+			result[key] = true
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func normalizeStatus(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func sortedKeys(values map[string]bool) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// markSuccess upgrades a deduplicated day to a successful contact.
+func (scholar *ScholarStats) markSuccess(date time.Time) {
+	day := dateOnly(date)
+	for idx := range scholar.Attempts {
+		if dateOnly(scholar.Attempts[idx].Date).Equal(day) {
+			scholar.Attempts[idx].Success = true
+		}
+	}
+	if scholar.LastSuccess.IsZero() || date.After(scholar.LastSuccess) {
+		scholar.LastSuccess = date
+	}
+}
+
+// summarizeAttempts returns the total failed attempts, failed attempts since
+// the last success, and the current run of back-to-back failures counted
+// back from the latest attempt.
+func summarizeAttempts(attempts []contactAttempt) (int, int, int) {
+	ordered := append([]contactAttempt{}, attempts...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Date.Before(ordered[j].Date)
+	})
+	failed, sinceSuccess := 0, 0
+	for _, attempt := range ordered {
+		if attempt.Success {
+			sinceSuccess = 0
+			continue
+		}
+		failed++
+		sinceSuccess++
+	}
+	streak := 0
+	for idx := len(ordered) - 1; idx >= 0 && !ordered[idx].Success; idx-- {
+		streak++
+	}
+	return failed, sinceSuccess, streak
+}
+
+func (opts AuditOptions) cadenceFor(program string) CadenceRule {
+	fallback := CadenceRule{CadenceDays: opts.CadenceDays, DueWindowDays: opts.DueWindowDays}
+	if opts.Policy == nil {
+		return fallback
+	}
+	if rule, ok := opts.Policy.Programs[policyKey(program)]; ok {
+		return rule
+	}
+	if opts.Policy.Default.CadenceDays > 0 {
+		return opts.Policy.Default
+	}
+	return fallback
+}
+
+func (policy *CadencePolicy) programCount() int {
+	if policy == nil {
+		return 0
+	}
+	return len(policy.Programs)
+}
+
+func policyKey(program string) string {
+	return strings.ToLower(strings.TrimSpace(program))
+}
+
+func buildOwnerSummary(buckets map[string][]ScholarSummary, tierNames []string) []OwnerSummary {
+	result := make([]OwnerSummary, 0, len(buckets))
+	for owner, entries := range buckets {
+		gaps := make([]int, 0, len(entries))
+		missedTotal := 0
+		for _, entry := range entries {
+			gaps = append(gaps, entry.GapDays)
+			missedTotal += entry.MissedCadences
+		}
+		avgGap, _, _ := summarizeGaps(gaps)
+		ownerSummary := OwnerSummary{
+			Owner:      owner,
+			Scholars:   len(entries),
+			AvgGapDays: avgGap,
+			TierCounts: countTiers(entries, tierNames),
+		}
+		if ownerSummary.Scholars > 0 {
+			ownerSummary.AvgMissedCadences = round1(float64(missedTotal) / float64(ownerSummary.Scholars))
+		}
+		result = append(result, ownerSummary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if moreSevere(result[i].TierCounts, result[j].TierCounts) {
+			return true
+		}
+		if moreSevere(result[j].TierCounts, result[i].TierCounts) {
+			return false
+		}
+		return result[i].Owner < result[j].Owner
+	})
+	return result
+}
+
+func rejectRate(rejected int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(rejected)/float64(total)*10000) / 10000
+}
+
 func summarizeGaps(gaps []int) (float64, float64, int) {
 	if len(gaps) == 0 {
 		return 0, 0, 0
@@ -559,8 +1833,7 @@ func averageIntervalDays(dates []time.Time) float64 {
 	})
 	totalDays := 0
 	for idx := 1; idx < len(normalized); idx++ {
-		diff := normalized[idx].Sub(normalized[idx-1])
-		totalDays += int(diff.Hours() / 24)
+		totalDays += daysBetween(normalized[idx-1], normalized[idx])
 	}
 	intervals := len(normalized) - 1
 	if intervals == 0 {
@@ -576,24 +1849,41 @@ func contactsPerMonth(contactCount int, daysSinceFirst int) float64 {
 	return round1(float64(contactCount) / float64(daysSinceFirst) * 30.0)
 }
 
-func countTiers(entries []ScholarSummary) (int, int, int, int) {
-	onTrack, dueSoon, overdue, critical := 0, 0, 0, 0
+func countTiers(entries []ScholarSummary, tierNames []string) []TierCount {
+	counts := make([]TierCount, len(tierNames))
+	index := make(map[string]int, len(tierNames))
+	for idx, name := range tierNames {
+		counts[idx] = TierCount{Tier: name}
+		index[name] = idx
+	}
 	for _, entry := range entries {
-		switch entry.Tier {
-		case "on_track":
-			onTrack++
-		case "due_soon":
-			dueSoon++
-		case "overdue":
-			overdue++
-		case "critical":
-			critical++
+		if pos, ok := index[entry.Tier]; ok {
+			counts[pos].Count++
 		}
 	}
-	return onTrack, dueSoon, overdue, critical
+	return counts
 }
 
-func gapDays(asOf time.Time, lastContact time.Time) int {
+func tierCount(counts []TierCount, name string) int {
+	for _, entry := range counts {
+		if entry.Tier == name {
+			return entry.Count
+		}
+	}
+	return 0
+}
+
+// moreSevere orders tier counts by comparing the most severe tier first.
+func moreSevere(a []TierCount, b []TierCount) bool {
+	for idx := len(a) - 1; idx >= 0 && idx < len(b); idx-- {
+		if a[idx].Count != b[idx].Count {
+			return a[idx].Count > b[idx].Count
+		}
+	}
+	return false
+}
+
+func gapDays(asOf time.Time, lastContact time.Time) int {
 	if lastContact.IsZero() {
 		return 0
 	}
@@ -602,21 +1892,170 @@ func gapDays(asOf time.Time, lastContact time.Time) int {
 	if lastDate.After(asOfDate) {
 		return 0
 	}
-	delta := asOfDate.Sub(lastDate)
-	return int(delta.Hours() / 24)
+	return daysBetween(lastDate, asOfDate)
 }
 
-func gapTier(gap int, cadenceDays int, dueWindowDays int) string {
-	if gap <= cadenceDays {
-		return "on_track"
+// daysBetween counts calendar days between two dates using their civil
+// dates, so DST transitions never shorten a day.
+func daysBetween(from time.Time, to time.Time) int {
+	return int(civilDay(to) - civilDay(from))
+}
+
+func civilDay(value time.Time) int64 {
+	year, month, day := value.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func defaultTierLadder() *TierLadder {
+	return &TierLadder{Tiers: []TierDefinition{
+		{Name: "on_track", MaxCadenceMultiple: floatPtr(1)},
+		{Name: "due_soon", MaxCadenceMultiple: floatPtr(1), PlusDueWindow: true},
+		{Name: "overdue", MaxCadenceMultiple: floatPtr(2)},
+		{Name: "critical"},
+	}}
+}
+
+func loadTierLadder(path string) (*TierLadder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
-	if gap <= cadenceDays+dueWindowDays {
-		return "due_soon"
+	var ladder TierLadder
+	if err := json.Unmarshal(data, &ladder); err != nil {
+		return nil, fmt.Errorf("invalid tier ladder %s: %w", path, err)
 	}
-	if gap <= cadenceDays*2 {
-		return "overdue"
+	if err := ladder.validate(); err != nil {
+		return nil, fmt.Errorf("invalid tier ladder %s: %w", path, err)
+	}
+	return &ladder, nil
+}
+
+func (ladder *TierLadder) validate() error {
+	if len(ladder.Tiers) < 2 {
+		return errors.New("at least two tiers are required")
+	}
+	seen := map[string]bool{}
+	// Bounds of the same kind must grow down the ladder, or the later tier
+	// could never be reached. Day and cadence bounds are not comparable
+	// without a cadence, so each kind is checked on its own.
+	var lastDays, lastMultiple *TierDefinition
+	for idx, tier := range ladder.Tiers {
+		name := strings.TrimSpace(tier.Name)
+		if name == "" {
+			return fmt.Errorf("tier %d is missing a name", idx+1)
+		}
+		if name == tierNeverContacted || name == tierScheduled {
+			return fmt.Errorf("tier name %s is reserved", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate tier %s", name)
+		}
+		seen[name] = true
+		bounded := tier.MaxDays != nil || tier.MaxCadenceMultiple != nil
+		if tier.MaxDays != nil && tier.MaxCadenceMultiple != nil {
+			return fmt.Errorf("tier %s sets both max_days and max_cadence_multiple", name)
+		}
+		last := idx == len(ladder.Tiers)-1
+		if last && bounded {
+			return fmt.Errorf("last tier %s must not set a bound", name)
+		}
+		if !last && !bounded {
+			return fmt.Errorf("tier %s needs max_days or max_cadence_multiple", name)
+		}
+		switch {
+		case tier.MaxDays != nil:
+			if lastDays != nil && *tier.MaxDays <= *lastDays.MaxDays {
+				return fmt.Errorf("tier %s max_days %d must exceed %s max_days %d", name, *tier.MaxDays, lastDays.Name, *lastDays.MaxDays)
+			}
+			lastDays = &ladder.Tiers[idx]
+		case tier.MaxCadenceMultiple != nil:
+			if lastMultiple != nil && !tier.reaches(*lastMultiple) {
+				return fmt.Errorf("tier %s cadence bound must exceed %s cadence bound", name, lastMultiple.Name)
+			}
+			lastMultiple = &ladder.Tiers[idx]
+		}
+		ladder.Tiers[idx].Name = name
+	}
+	return nil
+}
+
+// reaches reports whether a cadence-multiple bound extends past a previous
+// one: a larger multiple, or the same multiple with the due window added.
+func (tier TierDefinition) reaches(previous TierDefinition) bool {
+	if *tier.MaxCadenceMultiple != *previous.MaxCadenceMultiple {
+		return *tier.MaxCadenceMultiple > *previous.MaxCadenceMultiple
+	}
+	return tier.PlusDueWindow && !previous.PlusDueWindow
+}
+
+// classify returns the first tier whose bound covers the gap.
+func (ladder *TierLadder) classify(gap int, cadenceDays int, dueWindowDays int) string {
+	for _, tier := range ladder.Tiers {
+		switch {
+		case tier.MaxDays != nil:
+			if gap <= *tier.MaxDays {
+				return tier.Name
+			}
+		case tier.MaxCadenceMultiple != nil:
+			limit := *tier.MaxCadenceMultiple * float64(cadenceDays)
+			if tier.PlusDueWindow {
+				limit += float64(dueWindowDays)
+			}
+			if float64(gap) <= limit {
+				return tier.Name
+			}
+		default:
+			return tier.Name
+		}
+	}
+	return ladder.Tiers[len(ladder.Tiers)-1].Name
+}
+
+// names lists tiers from least to most severe, ending with never_contacted.
+func (ladder *TierLadder) names() []string {
+	result := make([]string, 0, len(ladder.Tiers)+1)
+	for _, tier := range ladder.Tiers {
+		result = append(result, tier.Name)
+	}
+	return append(result, tierNeverContacted)
+}
+
+// withScheduledTier ranks scheduled just above the first (on-track) tier so
+// booked scholars drop out of due and overdue alerts.
+func withScheduledTier(tierNames []string) []string {
+	result := make([]string, 0, len(tierNames)+1)
+	result = append(result, tierNames[0], tierScheduled)
+	return append(result, tierNames[1:]...)
+}
+
+// isScheduled reports whether a booked follow-up covers a scholar in one of
+// the middle ladder tiers (due_soon and overdue by default). The first tier
+// needs no booking, while the catch-all tier and never_contacted stay visible
+// however soon a contact is booked. The booking must land on or before the
+// next due date; once that date has passed, only bookings within one due
+// window of the as-of date count.
+func isScheduled(tier string, ladder *TierLadder, scheduled time.Time, nextDue time.Time, asOf time.Time, windowEnd time.Time) bool {
+	if scheduled.IsZero() || nextDue.IsZero() {
+		return false
+	}
+	middle := false
+	for idx := 1; idx < len(ladder.Tiers)-1; idx++ {
+		if ladder.Tiers[idx].Name == tier {
+			middle = true
+		}
+	}
+	if !middle {
+		return false
 	}
-	return "critical"
+	cutoff := dateOnly(nextDue)
+	if !cutoff.After(dateOnly(asOf)) {
+		cutoff = dateOnly(windowEnd)
+	}
+	return !dateOnly(scheduled).After(cutoff)
+}
+
+func (ladder *TierLadder) rank(value string) (int, bool) {
+	return tierRank(ladder.names(), value)
 }
 
 func missedCadences(gap int, cadenceDays int) int {
@@ -626,24 +2065,76 @@ func missedCadences(gap int, cadenceDays int) int {
 	if gap <= cadenceDays {
 		return 0
 	}
-	return (gap-cadenceDays+cadenceDays-1)/cadenceDays
+	return (gap - cadenceDays + cadenceDays - 1) / cadenceDays
 }
 
-func printReport(report Report, inputPath string) {
+func printReport(report Report) {
 	fmt.Println("Group Scholar Touchpoint Gap Audit")
 	fmt.Println(strings.Repeat("=", 38))
-	fmt.Printf("Input: %s\n", filepath.Base(inputPath))
-	fmt.Printf("As of: %s\n", report.Summary.AsOf)
-	fmt.Printf("Cadence: %d days (due window %d days)\n", report.Summary.CadenceDays, report.Summary.DueWindowDays)
+	if len(report.Sources) == 1 {
+		fmt.Printf("Input: %s\n", filepath.Base(report.Sources[0].Path))
+	} else {
+		fmt.Printf("Inputs: %d files\n", len(report.Sources))
+	}
+	if report.Summary.Timezone != "" {
+		fmt.Printf("As of: %s (%s)\n", report.Summary.AsOf, report.Summary.Timezone)
+	} else {
+		fmt.Printf("As of: %s\n", report.Summary.AsOf)
+	}
+	unit := "days"
+	if report.Summary.BusinessDays {
+		unit = "business days"
+	}
+	fmt.Printf("Cadence: %d %s (due window %d %s)\n", report.Summary.CadenceDays, unit, report.Summary.DueWindowDays, unit)
+	if holidays := len(report.Parameters.Holidays); holidays > 0 {
+		fmt.Printf("Holidays skipped: %d\n", holidays)
+	}
+	if report.Summary.PolicyPrograms > 0 {
+		fmt.Printf("Cadence policy overrides: %d programs\n", report.Summary.PolicyPrograms)
+	}
 	fmt.Printf("Total scholars: %d\n", report.Summary.TotalScholars)
-	fmt.Printf("Gap avg/median/max: %.1f / %.1f / %d days\n", report.Summary.AvgGapDays, report.Summary.MedianGapDays, report.Summary.MaxGapDays)
+	fmt.Printf("Gap avg/median/max: %.1f / %.1f / %d %s\n", report.Summary.AvgGapDays, report.Summary.MedianGapDays, report.Summary.MaxGapDays, unit)
 	fmt.Printf("Missed cadences avg/max: %.1f / %d\n", report.Summary.AvgMissedCadences, report.Summary.MaxMissedCadences)
-	fmt.Printf("On track: %d | Due soon: %d | Overdue: %d | Critical: %d\n", report.Summary.OnTrackCount, report.Summary.DueSoonCount, report.Summary.OverdueCount, report.Summary.CriticalCount)
+	fmt.Println(formatTierCounts(report.Summary.TierCounts, true))
+	if report.Summary.RosterScholars > 0 {
+		fmt.Printf("Roster scholars: %d\n", report.Summary.RosterScholars)
+	}
+	if report.Summary.BlackoutPeriods > 0 {
+		fmt.Printf("Blackout periods: %d (gaps skip blackout days)\n", report.Summary.BlackoutPeriods)
+	}
+	if report.Summary.ExcludedScholars > 0 {
+		fmt.Printf("Excluded scholars: %d | %s\n", report.Summary.ExcludedScholars, formatCounts(report.Summary.ExcludedReasons))
+	}
+	if report.Summary.SnoozedScholars > 0 {
+		verb := "suppressed"
+		if report.Summary.SnoozeMode == snoozeModeMark {
+			verb = "marked"
+		}
+		fmt.Printf("Snoozed scholars: %d (%s) | %s\n", report.Summary.SnoozedScholars, verb, formatCounts(report.Summary.SnoozeReasons))
+	}
+	if len(report.Summary.SuccessStatuses) > 0 {
+		fmt.Printf("Successful statuses: %s | failed attempts: %d\n", strings.Join(report.Summary.SuccessStatuses, ", "), report.Summary.FailedAttempts)
+	}
 	if report.Summary.InvalidRows > 0 {
 		fmt.Printf("Invalid rows skipped: %d\n", report.Summary.InvalidRows)
 	}
 	if report.Summary.FutureRows > 0 {
-		fmt.Printf("Future-dated rows ignored: %d\n", report.Summary.FutureRows)
+		if report.Summary.ScheduleFuture {
+			fmt.Printf("Scheduled touchpoints: %d\n", report.Summary.FutureRows)
+		} else {
+			fmt.Printf("Future-dated rows ignored: %d\n", report.Summary.FutureRows)
+		}
+	}
+	if report.Summary.RejectedRows > 0 {
+		fmt.Printf("Rejected rows: %d of %d (%.1f%%) | %s\n",
+			report.Summary.RejectedRows,
+			report.Summary.InputRows,
+			report.Summary.RejectRate*100,
+			formatCounts(report.Summary.RejectReasons),
+		)
+	}
+	if report.Summary.DuplicateRows > 0 {
+		fmt.Printf("Same-day duplicates merged: %d\n", report.Summary.DuplicateRows)
 	}
 	if len(report.DueSummary) > 0 {
 		fmt.Printf("Due buckets: %s\n", formatDueSummary(report.DueSummary))
@@ -652,6 +2143,20 @@ func printReport(report Report, inputPath string) {
 		fmt.Printf("Recency buckets: %s\n", formatRecencySummary(report.RecencySummary))
 	}
 
+	if len(report.Sources) > 1 {
+		fmt.Println("\nSources")
+		fmt.Println(strings.Repeat("-", 38))
+		for _, entry := range report.Sources {
+			fmt.Printf("%s | rows %d | accepted %d | invalid %d | future %d\n",
+				entry.Path,
+				entry.Rows,
+				entry.AcceptedRows,
+				entry.InvalidRows,
+				entry.FutureRows,
+			)
+		}
+	}
+
 	fmt.Println("\nTop gaps")
 	fmt.Println(strings.Repeat("-", 38))
 	if len(report.TopGaps) == 0 {
@@ -666,30 +2171,104 @@ func printReport(report Report, inputPath string) {
 			if channel == "" {
 				channel = "Unknown"
 			}
-			fmt.Printf("%s | %s | gap %d days | %s | last %s via %s\n",
+			if entry.LastContact.IsZero() {
+				enrolled := formatDate(entry.EnrollmentDate)
+				if enrolled == "" {
+					enrolled = "unknown"
+				}
+				scheduled := ""
+				if !entry.ScheduledDate.IsZero() {
+					scheduled = " | scheduled " + formatDate(entry.ScheduledDate)
+				}
+				if !entry.SnoozedUntil.IsZero() {
+					scheduled += " | snoozed until " + formatDate(entry.SnoozedUntil)
+				}
+				fmt.Printf("%s | %s | gap %d days | %s | enrolled %s%s\n",
+					entry.ScholarID,
+					program,
+					entry.GapDays,
+					entry.Tier,
+					enrolled,
+					scheduled,
+				)
+				continue
+			}
+			attempts := ""
+			if entry.SinceSuccess > 0 {
+				attempts = fmt.Sprintf(" | %d attempts since success", entry.SinceSuccess)
+			}
+			if !entry.ScheduledDate.IsZero() {
+				attempts += " | scheduled " + formatDate(entry.ScheduledDate)
+			}
+			if !entry.SnoozedUntil.IsZero() {
+				attempts += " | snoozed until " + formatDate(entry.SnoozedUntil)
+			}
+			fmt.Printf("%s | %s | gap %d days | %s | last %s via %s%s\n",
 				entry.ScholarID,
 				program,
 				entry.GapDays,
 				entry.Tier,
 				entry.LastContact.Format("2006-01-02"),
 				channel,
+				attempts,
 			)
 		}
 	}
 
+	if len(report.Excluded) > 0 {
+		fmt.Println("\nExcluded scholars")
+		fmt.Println(strings.Repeat("-", 38))
+		shown := report.Excluded
+		if report.Summary.TopN > 0 && len(shown) > report.Summary.TopN {
+			shown = shown[:report.Summary.TopN]
+		}
+		for _, entry := range shown {
+			reason := entry.Reason
+			if reason == "" {
+				reason = "Unspecified"
+			}
+			window := "open-ended"
+			switch {
+			case !entry.Start.IsZero() && !entry.End.IsZero():
+				window = formatDate(entry.Start) + " to " + formatDate(entry.End)
+			case !entry.Start.IsZero():
+				window = "since " + formatDate(entry.Start)
+			case !entry.End.IsZero():
+				window = "until " + formatDate(entry.End)
+			}
+			fmt.Printf("%s | %s | %s\n", entry.ScholarID, reason, window)
+		}
+		if hidden := len(report.Excluded) - len(shown); hidden > 0 {
+			fmt.Printf("... and %d more (see the JSON report)\n", hidden)
+		}
+	}
+
 	if len(report.ProgramSummary) > 0 {
 		fmt.Println("\nProgram summary")
 		fmt.Println(strings.Repeat("-", 38))
 		for _, entry := range report.ProgramSummary {
-			fmt.Printf("%s | scholars %d | avg gap %.1f | avg missed %.1f | on track %d | due soon %d | overdue %d | critical %d\n",
+			fmt.Printf("%s | cadence %d+%d | scholars %d | avg gap %.1f | avg missed %.1f | %s\n",
 				entry.Program,
+				entry.CadenceDays,
+				entry.DueWindowDays,
+				entry.Scholars,
+				entry.AvgGapDays,
+				entry.AvgMissedCadences,
+				formatTierCounts(entry.TierCounts, false),
+			)
+		}
+	}
+
+	if len(report.OwnerSummary) > 0 {
+		fmt.Println("\nOwner caseloads")
+		fmt.Println(strings.Repeat("-", 38))
+		for _, entry := range report.OwnerSummary {
+			fmt.Printf("%s | scholars %d | avg gap %.1f | avg missed %.1f | %s\n",
+				entry.Owner,
 				entry.Scholars,
 				entry.AvgGapDays,
 				entry.AvgMissedCadences,
-				entry.OnTrackCount,
-				entry.DueSoonCount,
-				entry.OverdueCount,
-				entry.CriticalCount,
+				formatTierCounts(entry.TierCounts, false),
 			)
 		}
 	}
@@ -721,6 +2300,62 @@ func printReport(report Report, inputPath string) {
 	}
 }
 
+func writeExports(report Report, paths ExportPaths) error {
+	if paths.JSON != "" {
+		if err := writeJSON(report, paths.JSON); err != nil {
+			return err
+		}
+		fmt.Printf("\nJSON report saved to %s\n", paths.JSON)
+	}
+	if paths.Alerts != "" {
+		alerts := report
+		if paths.AlertReasons != nil {
+			alerts = onlyAlerted(report, paths.AlertReasons)
+		}
+		if err := writeAlertsCSV(alerts, paths.Alerts, paths.MinTier); err != nil {
+			return err
+		}
+		fmt.Printf("Alert CSV saved to %s\n", paths.Alerts)
+	}
+	if paths.Programs != "" {
+		if err := writeProgramCSV(report, paths.Programs); err != nil {
+			return err
+		}
+		fmt.Printf("Program summary CSV saved to %s\n", paths.Programs)
+	}
+	if paths.Owners != "" {
+		if err := writeOwnerCSV(report, paths.Owners); err != nil {
+			return err
+		}
+		fmt.Printf("Owner summary CSV saved to %s\n", paths.Owners)
+	}
+	if paths.Channels != "" {
+		if err := writeChannelCSV(report, paths.Channels); err != nil {
+			return err
+		}
+		fmt.Printf("Channel summary CSV saved to %s\n", paths.Channels)
+	}
+	if paths.Statuses != "" {
+		if err := writeStatusCSV(report, paths.Statuses); err != nil {
+			return err
+		}
+		fmt.Printf("Status summary CSV saved to %s\n", paths.Statuses)
+	}
+	if paths.Due != "" {
+		if err := writeDueCSV(report, paths.Due); err != nil {
+			return err
+		}
+		fmt.Printf("Due summary CSV saved to %s\n", paths.Due)
+	}
+	if paths.Recency != "" {
+		if err := writeRecencyCSV(report, paths.Recency); err != nil {
+			return err
+		}
+		fmt.Printf("Recency summary CSV saved to %s\n", paths.Recency)
+	}
+	return nil
+}
+
 func writeJSON(report Report, path string) error {
 	data, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
@@ -748,710 +2383,3749 @@ func sanitizeSchema(value string) (string, error) {
 	return value, nil
 }
 
-func seedDatabase(report Report, cfg DBConfig) (string, error) {
+// ReportStore persists audit reports. Postgres is the default backend; a
+// sqlite://path URL keeps the same tables in a single local file.
+type ReportStore interface {
+	StoreReport(ctx context.Context, report Report, cfg DBConfig) (StoredRun, error)
+	RunCount(ctx context.Context) (int, error)
+	Close() error
+}
+
+const sqliteURLPrefix = "sqlite://"
+
+func isSQLiteURL(dbURL string) bool {
+	return strings.HasPrefix(strings.TrimSpace(dbURL), sqliteURLPrefix)
+}
+
+// dbBackendName names the backend a database URL selects, for console
+// messages.
+func dbBackendName(dbURL string) string {
+	if isSQLiteURL(dbURL) {
+		return "SQLite"
+	}
+	return "Postgres"
+}
+
+// requirePostgres guards the read-side commands, which query Postgres
+// directly and are not implemented for SQLite files yet.
+func requirePostgres(dbURL, command string) error {
+	if isSQLiteURL(dbURL) {
+		return fmt.Errorf("%s needs a Postgres database; sqlite:// URLs are only supported for storing runs", command)
+	}
+	return nil
+}
+
+// openReportStore connects to the configured backend and brings its schema
+// up to date before any report is written.
+func openReportStore(ctx context.Context, cfg DBConfig) (ReportStore, error) {
+	if isSQLiteURL(cfg.URL) {
+		return openSQLiteStore(ctx, strings.TrimPrefix(strings.TrimSpace(cfg.URL), sqliteURLPrefix))
+	}
+
 	schema, err := sanitizeSchema(cfg.Schema)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
 	db, err := sql.Open("pgx", cfg.URL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer db.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
-	defer cancel()
-
 	if err := db.PingContext(ctx); err != nil {
-		return "", err
+		db.Close()
+		return nil, err
 	}
-
 	if err := ensureSchema(ctx, db, schema); err != nil {
-		return "", err
+		db.Close()
+		return nil, err
 	}
+	return &postgresStore{db: db, schema: schema}, nil
+}
+
+type postgresStore struct {
+	db     *sql.DB
+	schema string
+}
+
+func (store *postgresStore) StoreReport(ctx context.Context, report Report, cfg DBConfig) (StoredRun, error) {
+	return storeReportTx(ctx, store.db, report, store.schema, cfg)
+}
 
+func (store *postgresStore) RunCount(ctx context.Context) (int, error) {
 	var count int
-	if err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s.audit_runs`, schema)).Scan(&count); err != nil {
-		return "", err
-	}
-	if count > 0 {
-		fmt.Println("Audit data already present; skipping seed.")
-		return "", nil
-	}
+	err := store.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s.audit_runs`, store.schema)).Scan(&count)
+	return count, err
+}
 
-	return storeReportTx(ctx, db, report, schema, cfg.Tag)
+func (store *postgresStore) Close() error {
+	return store.db.Close()
 }
 
-func storeReportInDB(report Report, cfg DBConfig) (string, error) {
-	schema, err := sanitizeSchema(cfg.Schema)
+// sqliteStore keeps the audit tables in a single local file. Dates are
+// stored as YYYY-MM-DD text and arrays as JSON text so the file stays
+// readable from the sqlite3 shell.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// sqliteDSN builds a file: URI for the driver. The path is escaped so a
+// '?', '#', or '%' in a file name cannot be mistaken for query options.
+func sqliteDSN(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(10000)")
+	query.Set("_txlock", "immediate")
+	dsn := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: query.Encode()}
+	return dsn.String()
+}
+
+func openSQLiteStore(ctx context.Context, path string) (*sqliteStore, error) {
+	if path == "" {
+		return nil, errors.New("sqlite URL needs a file path, e.g. sqlite://audit.db")
+	}
+	db, err := sql.Open("sqlite", sqliteDSN(path))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureSQLiteSchema(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStore{db: db}, nil
+}
 
-	db, err := sql.Open("pgx", cfg.URL)
+// ensureSQLiteSchema mirrors ensureSchema for the SQLite migration set. A
+// file only ever has one writer at a time, so no advisory lock is needed.
+func ensureSQLiteSchema(ctx context.Context, db *sql.DB) error {
+	migrations, err := loadMigrations(sqliteMigrations)
 	if err != nil {
-		return "", err
+		return err
 	}
-	defer db.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(ctx); err != nil {
-		return "", err
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version integer PRIMARY KEY,
+			name text NOT NULL,
+			applied_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`); err != nil {
+		return err
+	}
+	var current int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
 	}
+	if latest := latestMigrationVersion(migrations); current > latest {
+		return fmt.Errorf("sqlite database is at version %d but this binary only knows up to %d; upgrade the binary", current, latest)
+	}
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := applySQLiteMigration(ctx, db, migration); err != nil {
+			return fmt.Errorf("apply migration %s: %w", migration.Name, err)
+		}
+	}
+	return nil
+}
 
-	if err := ensureSchema(ctx, db, schema); err != nil {
-		return "", err
+// applySQLiteMigration runs one migration with foreign keys switched off,
+// the documented way to rebuild a table SQLite cannot alter in place, and
+// checks the keys again before committing.
+func applySQLiteMigration(ctx context.Context, db *sql.DB, migration Migration) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return err
 	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`)
+	}()
 
-	return storeReportTx(ctx, db, report, schema, cfg.Tag)
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return err
+	}
+	violated := rows.Next()
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if violated {
+		return errors.New("migration left foreign key violations")
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, migration.Version, migration.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func storeReportTx(ctx context.Context, db *sql.DB, report Report, schema string, tag string) (string, error) {
+func (store *sqliteStore) StoreReport(ctx context.Context, report Report, cfg DBConfig) (stored StoredRun, err error) {
 	runID := uuid.New()
 	asOfDate, err := parseDate(report.Summary.AsOf)
 	if err != nil {
-		return "", err
+		return StoredRun{}, err
 	}
+	asOfDate = dateOnly(asOfDate)
 
-	tx, err := db.BeginTx(ctx, nil)
+	// _txlock=immediate takes the write lock up front, which serializes
+	// fingerprint checks the way the Postgres advisory lock does.
+	tx, err := store.db.BeginTx(ctx, nil)
 	if err != nil {
-		return "", err
+		return StoredRun{}, err
 	}
 	defer func() {
-		if err != nil {
+		if err != nil || stored.Skipped {
 			_ = tx.Rollback()
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
-		INSERT INTO %s.audit_runs (
-			id, as_of, cadence_days, due_window_days, total_scholars,
-			avg_gap_days, median_gap_days, max_gap_days, avg_missed_cadences,
-			max_missed_cadences, on_track_count, due_soon_count, overdue_count,
-			critical_count, invalid_rows, future_rows, run_tag
-		) VALUES (
-			$1,$2,$3,$4,$5,
-			$6,$7,$8,$9,$10,
-			$11,$12,$13,$14,
-			$15,$16,$17,$18
-		)`, schema),
-		runID,
-		dateOnly(asOfDate),
-		report.Summary.CadenceDays,
-		report.Summary.DueWindowDays,
-		report.Summary.TotalScholars,
-		report.Summary.AvgGapDays,
-		report.Summary.MedianGapDays,
-		report.Summary.MaxGapDays,
-		report.Summary.AvgMissedCadences,
-		report.Summary.MaxMissedCadences,
-		report.Summary.OnTrackCount,
-		report.Summary.DueSoonCount,
-		report.Summary.OverdueCount,
-		report.Summary.CriticalCount,
-		report.Summary.InvalidRows,
-		report.Summary.FutureRows,
-		nullString(tag),
-	)
-	if err != nil {
-		_ = tx.Rollback()
-		return "", err
+	fingerprint := report.Summary.InputFingerprint
+	existing := ""
+	if fingerprint != "" && cfg.Mode != "" && cfg.Mode != storeModeAppend {
+		switch cfg.Mode {
+		case storeModeSkip:
+			err = tx.QueryRowContext(ctx, `
+				SELECT id FROM audit_runs
+				WHERE input_fingerprint = ? AND run_tag IS ?
+				ORDER BY created_at DESC
+				LIMIT 1`, fingerprint, nullString(cfg.Tag)).Scan(&existing)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return StoredRun{}, err
+			}
+			err = nil
+		case storeModeReplace:
+			result, err := tx.ExecContext(ctx, `DELETE FROM audit_runs WHERE input_fingerprint = ? AND run_tag IS ?`, fingerprint, nullString(cfg.Tag))
+			if err != nil {
+				return StoredRun{}, err
+			}
+			if stored.Replaced, err = result.RowsAffected(); err != nil {
+				return StoredRun{}, err
+			}
+		}
 	}
 
-	insertScholarSQL := fmt.Sprintf(`
-		INSERT INTO %s.audit_scholar_gaps (
-			id, run_id, scholar_id, program, last_channel, last_status,
-			last_contact, first_contact, next_due_date, contact_count, gap_days, days_past_due,
-			missed_cadences, days_since_first_contact, avg_interval_days, contacts_per_month, tier
-		) VALUES (
-			$1,$2,$3,$4,$5,$6,
-			$7,$8,$9,$10,$11,$12,
-			$13,$14,$15,$16,$17
-		)`, schema)
+	prior, err := loadSQLiteTierStates(ctx, tx, cfg.Tag, asOfDate)
+	if err != nil {
+		return StoredRun{}, err
+	}
+	since, transitions := tierTransitions(report, prior, asOfDate)
+	if existing != "" {
+		return StoredRun{RunID: existing, Skipped: true, TierSince: since}, nil
+	}
+	report = withTierSince(report, since)
+	stored.TierSince = since
 
-	for _, entry := range report.Scholars {
-		lastContact := nullDate(entry.LastContact)
-		firstContact := nullDate(entry.FirstContact)
-		nextDue := nullDate(entry.NextDueDate)
-		_, err = tx.ExecContext(ctx, insertScholarSQL,
-			uuid.New(),
-			runID,
-			entry.ScholarID,
-			nullString(entry.Program),
-			nullString(entry.LastChannel),
-			nullString(entry.LastStatus),
-			lastContact,
-			firstContact,
-			nextDue,
-			entry.ContactCount,
-			entry.GapDays,
-			entry.DaysPastDue,
-			entry.MissedCadences,
-			entry.DaysSinceFirst,
-			entry.AvgIntervalDays,
-			entry.ContactsPerMonth,
-			entry.Tier,
-		)
-		if err != nil {
-			_ = tx.Rollback()
-			return "", err
+	run, err := reportRunRow(report, cfg.Tag, runID, asOfDate)
+	if err != nil {
+		return StoredRun{}, err
+	}
+	tables := append([]copyTable{run}, reportCopyTables(report, runID)...)
+	tables = append(tables, tierHistoryTable(runID, asOfDate, transitions))
+	for _, table := range tables {
+		if err = insertSQLiteRows(ctx, tx, table, cfg.Progress); err != nil {
+			return StoredRun{}, fmt.Errorf("insert %s: %w", table.table, err)
 		}
 	}
 
-	insertProgramSQL := fmt.Sprintf(`
-		INSERT INTO %s.audit_program_summary (
-			id, run_id, program, scholars, avg_gap_days, avg_missed_cadences,
-			on_track_count, due_soon_count, overdue_count, critical_count
-		) VALUES (
-			$1,$2,$3,$4,$5,$6,
-			$7,$8,$9,$10
-		)`, schema)
+	if err = tx.Commit(); err != nil {
+		return StoredRun{}, err
+	}
+	stored.RunID = runID.String()
+	return stored, nil
+}
 
-	for _, entry := range report.ProgramSummary {
-		_, err = tx.ExecContext(ctx, insertProgramSQL,
-			uuid.New(),
-			runID,
-			entry.Program,
-			entry.Scholars,
-			entry.AvgGapDays,
-			entry.AvgMissedCadences,
-			entry.OnTrackCount,
-			entry.DueSoonCount,
-			entry.OverdueCount,
-			entry.CriticalCount,
+// loadSQLiteTierStates is loadTierStates for SQLite, which lacks DISTINCT
+// ON and array parameters; rowid breaks ties between same-second stores.
+func loadSQLiteTierStates(ctx context.Context, tx *sql.Tx, tag string, asOf time.Time) (map[string]tierState, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT scholar_id, to_tier, as_of
+		FROM (
+			SELECT h.scholar_id, h.to_tier, h.as_of,
+				ROW_NUMBER() OVER (PARTITION BY h.scholar_id ORDER BY h.as_of DESC, h.created_at DESC, h.rowid DESC) AS position
+			FROM scholar_tier_history h
+			JOIN audit_runs r ON r.id = h.run_id
+			WHERE h.as_of <= ? AND r.run_tag IS ?
 		)
-		if err != nil {
-			_ = tx.Rollback()
-			return "", err
+		WHERE position = 1`, formatDate(asOf), nullString(tag))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	states := map[string]tierState{}
+	for rows.Next() {
+		var scholarID, since string
+		var state tierState
+		if err := rows.Scan(&scholarID, &state.Tier, &since); err != nil {
+			return nil, err
 		}
-	}
-
-	insertChannelSQL := fmt.Sprintf(`
-		INSERT INTO %s.audit_channel_summary (
-			id, run_id, channel, touchpoint_count
-		) VALUES (
-			$1,$2,$3,$4
-		)`, schema)
-
-	for channel, count := range report.ChannelSummary {
-		_, err = tx.ExecContext(ctx, insertChannelSQL,
-			uuid.New(),
-			runID,
-			channel,
-			count,
-		)
-		if err != nil {
-			_ = tx.Rollback()
-			return "", err
+		if state.Since, err = parseDate(since); err != nil {
+			return nil, fmt.Errorf("scholar_tier_history %s: %w", scholarID, err)
 		}
+		states[scholarID] = state
 	}
+	return states, rows.Err()
+}
 
-	insertStatusSQL := fmt.Sprintf(`
-		INSERT INTO %s.audit_status_summary (
-			id, run_id, status, touchpoint_count
-		) VALUES (
-			$1,$2,$3,$4
-		)`, schema)
+func insertSQLiteRows(ctx context.Context, tx *sql.Tx, table copyTable, progress io.Writer) error {
+	if len(table.rows) == 0 {
+		return nil
+	}
+	stmt, err := tx.PrepareContext(ctx, insertSQL(table.table, table.columns, sqlitePlaceholder))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
 
-	for status, count := range report.StatusSummary {
-		_, err = tx.ExecContext(ctx, insertStatusSQL,
-			uuid.New(),
-			runID,
-			status,
-			count,
-		)
-		if err != nil {
-			_ = tx.Rollback()
-			return "", err
+	values := make([]any, len(table.columns))
+	for i, row := range table.rows {
+		if i > 0 && progress != nil && i%copyProgressEvery == 0 {
+			fmt.Fprintf(progress, "  %s: %d/%d rows\n", table.table, i, len(table.rows))
+		}
+		for j, value := range row {
+			if values[j], err = sqliteValue(value); err != nil {
+				return err
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return err
 		}
 	}
+	if progress != nil {
+		fmt.Fprintf(progress, "Inserted %d rows into %s\n", len(table.rows), table.table)
+	}
+	return nil
+}
 
-	insertRecencySQL := fmt.Sprintf(`
-		INSERT INTO %s.audit_recency_summary (
-			id, run_id, label, min_days, max_days, bucket_count
-		) VALUES (
-			$1,$2,$3,$4,$5,$6
-		)`, schema)
+func sqlitePlaceholder(int) string {
+	return "?"
+}
 
-	for _, entry := range report.RecencySummary {
-		_, err = tx.ExecContext(ctx, insertRecencySQL,
-			uuid.New(),
-			runID,
-			entry.Label,
-			nullInt(entry.MinDays),
-			nullInt(entry.MaxDays),
-			entry.Count,
-		)
+// sqliteValue converts the Postgres-shaped row values from reportCopyTables
+// into the text encodings used by the SQLite schema.
+func sqliteValue(value any) (any, error) {
+	switch typed := value.(type) {
+	case uuid.UUID:
+		return typed.String(), nil
+	case time.Time:
+		return formatDate(typed), nil
+	case sql.NullTime:
+		if !typed.Valid {
+			return nil, nil
+		}
+		return formatDate(typed.Time), nil
+	case []string:
+		data, err := json.Marshal(typed)
 		if err != nil {
-			_ = tx.Rollback()
-			return "", err
+			return nil, err
 		}
+		return string(data), nil
+	default:
+		return value, nil
 	}
+}
 
-	if err := tx.Commit(); err != nil {
-		return "", err
-	}
-	return runID.String(), nil
+func (store *sqliteStore) RunCount(ctx context.Context) (int, error) {
+	var count int
+	err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_runs`).Scan(&count)
+	return count, err
 }
 
-func ensureSchema(ctx context.Context, db *sql.DB, schema string) error {
-	if _, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, schema)); err != nil {
-		return err
-	}
+func (store *sqliteStore) Close() error {
+	return store.db.Close()
+}
 
-	_, err := db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_runs (
-			id uuid PRIMARY KEY,
-			as_of date NOT NULL,
-			cadence_days integer NOT NULL,
-			due_window_days integer NOT NULL,
-			total_scholars integer NOT NULL,
-			avg_gap_days numeric(8,2) NOT NULL,
-			median_gap_days numeric(8,2) NOT NULL,
-			max_gap_days integer NOT NULL,
-			avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0,
-			max_missed_cadences integer NOT NULL DEFAULT 0,
-			on_track_count integer NOT NULL,
-			due_soon_count integer NOT NULL,
-			overdue_count integer NOT NULL,
-			critical_count integer NOT NULL,
-			invalid_rows integer NOT NULL,
-			future_rows integer NOT NULL DEFAULT 0,
-			run_tag text,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema))
+func seedDatabase(report Report, cfg DBConfig) (StoredRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+
+	store, err := openReportStore(ctx, cfg)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
+	defer store.Close()
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_runs
-		ADD COLUMN IF NOT EXISTS future_rows integer NOT NULL DEFAULT 0
-	`, schema))
+	count, err := store.RunCount(ctx)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_runs
-		ADD COLUMN IF NOT EXISTS avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0
-	`, schema))
-	if err != nil {
-		return err
+	if count > 0 {
+		fmt.Println("Audit data already present; skipping seed.")
+		return StoredRun{}, nil
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_runs
-		ADD COLUMN IF NOT EXISTS max_missed_cadences integer NOT NULL DEFAULT 0
-	`, schema))
+
+	return store.StoreReport(ctx, report, cfg)
+}
+
+func storeReportInDB(report Report, cfg DBConfig) (StoredRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+
+	store, err := openReportStore(ctx, cfg)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
+	defer store.Close()
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_scholar_gaps (
-			id uuid PRIMARY KEY,
-			run_id uuid NOT NULL REFERENCES %s.audit_runs(id) ON DELETE CASCADE,
-			scholar_id text NOT NULL,
-			program text,
-			last_channel text,
-			last_status text,
-			last_contact date,
-			first_contact date,
-			next_due_date date,
-			contact_count integer NOT NULL,
-			gap_days integer NOT NULL,
-			days_past_due integer NOT NULL,
-			days_since_first_contact integer NOT NULL DEFAULT 0,
-			avg_interval_days numeric(8,2) NOT NULL DEFAULT 0,
-			contacts_per_month numeric(8,2) NOT NULL DEFAULT 0,
-			tier text NOT NULL,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema, schema))
+	return store.StoreReport(ctx, report, cfg)
+}
+
+// storeReplayInDB writes each replay snapshot as its own audit run so trend
+// queries over audit_runs see the backfilled history.
+func storeReplayInDB(replay Replay, cfg DBConfig) ([]StoredRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+
+	store, err := openReportStore(ctx, cfg)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer store.Close()
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS first_contact date
-	`, schema))
-	if err != nil {
-		return err
+	stored := make([]StoredRun, 0, len(replay.Snapshots))
+	for _, snapshot := range replay.Snapshots {
+		snapshotCtx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+		run, err := store.StoreReport(snapshotCtx, snapshot.report, cfg)
+		cancel()
+		if err != nil {
+			return stored, fmt.Errorf("store replay %s: %w", snapshot.Summary.AsOf, err)
+		}
+		stored = append(stored, run)
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS next_due_date date
-	`, schema))
+	return stored, nil
+}
+
+// storeReportTx writes a report in one transaction. The run row is a plain
+// insert; every per-scholar and summary table is loaded with COPY so large
+// rosters persist in a handful of round trips.
+func storeReportTx(ctx context.Context, db *sql.DB, report Report, schema string, cfg DBConfig) (StoredRun, error) {
+	runID := uuid.New()
+	asOfDate, err := parseDate(report.Summary.AsOf)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS days_past_due integer NOT NULL DEFAULT 0
-	`, schema))
+
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS days_since_first_contact integer NOT NULL DEFAULT 0
-	`, schema))
-	if err != nil {
+	defer conn.Close()
+
+	var stored StoredRun
+	err = conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected database driver connection %T", driverConn)
+		}
+		var err error
+		stored, err = copyReport(ctx, stdConn.Conn(), report, schema, cfg, runID, dateOnly(asOfDate))
 		return err
-	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS avg_interval_days numeric(8,2) NOT NULL DEFAULT 0
-	`, schema))
+	})
+	return stored, err
+}
+
+func copyReport(ctx context.Context, conn *pgx.Conn, report Report, schema string, cfg DBConfig, runID uuid.UUID, asOfDate time.Time) (stored StoredRun, err error) {
+	tx, err := conn.Begin(ctx)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		ALTER TABLE %s.audit_scholar_gaps
-		ADD COLUMN IF NOT EXISTS contacts_per_month numeric(8,2) NOT NULL DEFAULT 0
-	`, schema))
-	if err != nil {
-		return err
+	defer func() {
+		if err != nil || stored.Skipped {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	fingerprint := report.Summary.InputFingerprint
+	existing := ""
+	if fingerprint != "" && cfg.Mode != "" && cfg.Mode != storeModeAppend {
+		// Serialize stores of the same fingerprint and tag so two concurrent
+		// runs cannot both miss each other and insert duplicates.
+		if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, schema+".audit_runs:"+fingerprint+":"+cfg.Tag); err != nil {
+			return StoredRun{}, err
+		}
+		switch cfg.Mode {
+		case storeModeSkip:
+			err = tx.QueryRow(ctx, fmt.Sprintf(`
+				SELECT id::text FROM %s.audit_runs
+				WHERE input_fingerprint = $1 AND run_tag IS NOT DISTINCT FROM $2
+				ORDER BY created_at DESC
+				LIMIT 1`, schema), fingerprint, nullString(cfg.Tag)).Scan(&existing)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return StoredRun{}, err
+			}
+			err = nil
+		case storeModeReplace:
+			result, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.audit_runs WHERE input_fingerprint = $1 AND run_tag IS NOT DISTINCT FROM $2`, schema), fingerprint, nullString(cfg.Tag))
+			if err != nil {
+				return StoredRun{}, err
+			}
+			stored.Replaced = result.RowsAffected()
+		}
 	}
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_program_summary (
-			id uuid PRIMARY KEY,
-			run_id uuid NOT NULL REFERENCES %s.audit_runs(id) ON DELETE CASCADE,
-			program text NOT NULL,
-			scholars integer NOT NULL,
-			avg_gap_days numeric(8,2) NOT NULL,
-			on_track_count integer NOT NULL,
-			due_soon_count integer NOT NULL,
-			overdue_count integer NOT NULL,
-			critical_count integer NOT NULL,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema, schema))
+	prior, err := loadTierStates(ctx, tx, schema, cfg.Tag, report, asOfDate)
 	if err != nil {
-		return err
+		return StoredRun{}, err
 	}
+	since, transitions := tierTransitions(report, prior, asOfDate)
+	if existing != "" {
+		return StoredRun{RunID: existing, Skipped: true, TierSince: since}, nil
+	}
+	report = withTierSince(report, since)
+	stored.TierSince = since
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_channel_summary (
-			id uuid PRIMARY KEY,
-			run_id uuid NOT NULL REFERENCES %s.audit_runs(id) ON DELETE CASCADE,
-			channel text NOT NULL,
-			touchpoint_count integer NOT NULL,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema, schema))
+	run, err := reportRunRow(report, cfg.Tag, runID, asOfDate)
 	if err != nil {
-		return err
+		return StoredRun{}, err
+	}
+	if _, err = tx.Exec(ctx, insertSQL(schema+"."+run.table, run.columns, postgresPlaceholder), run.rows[0]...); err != nil {
+		return StoredRun{}, err
 	}
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_status_summary (
-			id uuid PRIMARY KEY,
-			run_id uuid NOT NULL REFERENCES %s.audit_runs(id) ON DELETE CASCADE,
-			status text NOT NULL,
-			touchpoint_count integer NOT NULL,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema, schema))
-	if err != nil {
-		return err
+	tables := append(reportCopyTables(report, runID), tierHistoryTable(runID, asOfDate, transitions))
+	for _, table := range tables {
+		if len(table.rows) == 0 {
+			continue
+		}
+		source := &progressCopySource{rows: table.rows, table: table.table, progress: cfg.Progress, every: copyProgressEvery}
+		if _, err = tx.CopyFrom(ctx, pgx.Identifier{schema, table.table}, table.columns, source); err != nil {
+			return StoredRun{}, fmt.Errorf("copy %s: %w", table.table, err)
+		}
+		if cfg.Progress != nil {
+			fmt.Fprintf(cfg.Progress, "Copied %d rows into %s\n", len(table.rows), table.table)
+		}
 	}
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s.audit_recency_summary (
-			id uuid PRIMARY KEY,
-			run_id uuid NOT NULL REFERENCES %s.audit_runs(id) ON DELETE CASCADE,
-			label text NOT NULL,
-			min_days integer,
-			max_days integer,
-			bucket_count integer NOT NULL,
-			created_at timestamptz NOT NULL DEFAULT now()
-		)`, schema, schema))
-	if err != nil {
-		return err
+	if err = tx.Commit(ctx); err != nil {
+		return StoredRun{}, err
 	}
+	stored.RunID = runID.String()
+	return stored, nil
+}
 
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_scholar_gaps_run_idx ON %s.audit_scholar_gaps (run_id)`, schema, schema))
+// reportRunRow lays out the audit_runs row shared by every storage
+// backend, as a single-row copyTable.
+func reportRunRow(report Report, tag string, runID uuid.UUID, asOfDate time.Time) (copyTable, error) {
+	parameters, err := json.Marshal(report.Parameters)
 	if err != nil {
-		return err
+		return copyTable{}, err
+	}
+	successStatuses := report.Summary.SuccessStatuses
+	if successStatuses == nil {
+		successStatuses = []string{}
+	}
+	legacy := legacyTierColumns(report.Summary.TierCounts, report.Tiers)
+	return copyTable{
+		table: "audit_runs",
+		columns: []string{
+			"id", "as_of", "cadence_days", "due_window_days", "total_scholars",
+			"avg_gap_days", "median_gap_days", "max_gap_days", "avg_missed_cadences",
+			"max_missed_cadences", "on_track_count", "due_soon_count", "overdue_count",
+			"critical_count", "never_contacted_count", "invalid_rows", "future_rows", "failed_attempts", "run_tag",
+			"input_files", "timezone", "schedule_future", "input_fingerprint",
+			"dedupe_day", "top_n", "min_tier", "tool_version", "success_statuses", "tiers", "parameters",
+			"business_days",
+		},
+		rows: [][]any{{
+			runID,
+			asOfDate,
+			report.Summary.CadenceDays,
+			report.Summary.DueWindowDays,
+			report.Summary.TotalScholars,
+			report.Summary.AvgGapDays,
+			report.Summary.MedianGapDays,
+			report.Summary.MaxGapDays,
+			report.Summary.AvgMissedCadences,
+			report.Summary.MaxMissedCadences,
+			legacy[0], legacy[1], legacy[2], legacy[3], legacy[4],
+			report.Summary.InvalidRows,
+			report.Summary.FutureRows,
+			report.Summary.FailedAttempts,
+			nullString(tag),
+			report.inputFiles(),
+			nullString(report.Summary.Timezone),
+			report.Summary.ScheduleFuture,
+			nullString(report.Summary.InputFingerprint),
+			report.Summary.DedupeDay,
+			report.Summary.TopN,
+			nullString(report.Summary.MinTier),
+			nullString(report.Summary.ToolVersion),
+			successStatuses,
+			report.Tiers,
+			string(parameters),
+			report.Summary.BusinessDays,
+		}},
+	}, nil
+}
+
+// legacyTierColumns fills the fixed on_track_count through
+// never_contacted_count columns. The first four only mean something for the
+// default ladder, so custom ladders store NULL there and leave the real
+// counts to audit_tier_counts.
+func legacyTierColumns(counts []TierCount, tierNames []string) []any {
+	values := make([]any, 0, 5)
+	defaults := hasDefaultTiers(tierNames)
+	for _, name := range []string{"on_track", "due_soon", "overdue", "critical"} {
+		if !defaults {
+			values = append(values, sql.NullInt64{})
+			continue
+		}
+		values = append(values, tierCount(counts, name))
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_scholar_gaps_tier_idx ON %s.audit_scholar_gaps (tier)`, schema, schema))
-	if err != nil {
-		return err
+	return append(values, tierCount(counts, tierNeverContacted))
+}
+
+// hasDefaultTiers reports whether tier names come from the built-in ladder,
+// ignoring the scheduled tier added by --schedule-future.
+func hasDefaultTiers(tierNames []string) bool {
+	defaults := defaultTierLadder().names()
+	names := make([]string, 0, len(tierNames))
+	for _, name := range tierNames {
+		if name != tierScheduled {
+			names = append(names, name)
+		}
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_program_summary_run_idx ON %s.audit_program_summary (run_id)`, schema, schema))
-	if err != nil {
-		return err
+	if len(names) != len(defaults) {
+		return false
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_channel_summary_run_idx ON %s.audit_channel_summary (run_id)`, schema, schema))
-	if err != nil {
-		return err
+	for idx, name := range names {
+		if name != defaults[idx] {
+			return false
+		}
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_status_summary_run_idx ON %s.audit_status_summary (run_id)`, schema, schema))
-	if err != nil {
-		return err
+	return true
+}
+
+func insertSQL(table string, columns []string, placeholder func(int) string) string {
+	marks := make([]string, len(columns))
+	for i := range columns {
+		marks[i] = placeholder(i + 1)
 	}
-	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_audit_recency_summary_run_idx ON %s.audit_recency_summary (run_id)`, schema, schema))
-	return err
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(marks, ", "))
 }
 
-func nullString(value string) sql.NullString {
-	if strings.TrimSpace(value) == "" {
-		return sql.NullString{}
+func postgresPlaceholder(position int) string {
+	return fmt.Sprintf("$%d", position)
+}
+
+// tierState is a scholar's latest entry in scholar_tier_history.
+type tierState struct {
+	Tier  string
+	Since time.Time
+}
+
+type tierTransition struct {
+	ScholarID string
+	FromTier  string
+	ToTier    string
+}
+
+// tierTransitions compares a report against each scholar's last recorded
+// tier. Scholars still in that tier keep its since date; everyone else
+// starts a new stint on the report's as-of date and gets a transition row.
+func tierTransitions(report Report, prior map[string]tierState, asOf time.Time) (map[string]time.Time, []tierTransition) {
+	since := make(map[string]time.Time, len(report.Scholars))
+	transitions := []tierTransition{}
+	for _, entry := range report.Scholars {
+		state, ok := prior[entry.ScholarID]
+		if ok && state.Tier == entry.Tier {
+			since[entry.ScholarID] = state.Since
+			continue
+		}
+		since[entry.ScholarID] = asOf
+		transitions = append(transitions, tierTransition{ScholarID: entry.ScholarID, FromTier: state.Tier, ToTier: entry.Tier})
 	}
-	return sql.NullString{String: value, Valid: true}
+	return since, transitions
 }
 
-func nullDate(value time.Time) sql.NullTime {
-	if value.IsZero() {
-		return sql.NullTime{}
+// withTierSince returns a copy of the report with tier_since and
+// days_in_tier filled in for every scholar found in since.
+func withTierSince(report Report, since map[string]time.Time) Report {
+	if len(since) == 0 {
+		return report
+	}
+	asOf, err := parseDate(report.Summary.AsOf)
+	if err != nil {
+		return report
+	}
+	annotate := func(entries []ScholarSummary) []ScholarSummary {
+		annotated := make([]ScholarSummary, len(entries))
+		for i, entry := range entries {
+			if date, ok := since[entry.ScholarID]; ok {
+				days := daysBetween(date, asOf)
+				entry.TierSince = date
+				entry.DaysInTier = &days
+			}
+			annotated[i] = entry
+		}
+		return annotated
 	}
-	return sql.NullTime{Time: dateOnly(value), Valid: true}
+	report.Scholars = annotate(report.Scholars)
+	report.TopGaps = annotate(report.TopGaps)
+	return report
 }
 
-func nullInt(value *int) sql.NullInt64 {
-	if value == nil {
-		return sql.NullInt64{}
+func tierHistoryTable(runID uuid.UUID, asOf time.Time, transitions []tierTransition) copyTable {
+	rows := make([][]any, 0, len(transitions))
+	for _, transition := range transitions {
+		rows = append(rows, []any{uuid.New(), runID, transition.ScholarID, nullString(transition.FromTier), transition.ToTier, asOf})
+	}
+	return copyTable{
+		table:   "scholar_tier_history",
+		columns: []string{"id", "run_id", "scholar_id", "from_tier", "to_tier", "as_of"},
+		rows:    rows,
 	}
-	return sql.NullInt64{Int64: int64(*value), Valid: true}
 }
 
-func writeAlertsCSV(report Report, path string, minTier string) error {
-	threshold, ok := tierRank(minTier)
-	if !ok {
-		return fmt.Errorf("invalid --min-tier value: %s", minTier)
+func reportScholarIDs(report Report) []string {
+	ids := make([]string, 0, len(report.Scholars))
+	for _, entry := range report.Scholars {
+		ids = append(ids, entry.ScholarID)
 	}
+	return ids
+}
 
-	file, err := os.Create(path)
+// loadTierStates reads each scholar's latest tier transition on or before
+// asOf among runs stored under the same tag. Runs are expected to be stored
+// in as-of order; a backfill stored after newer runs only sees the history
+// that precedes it.
+func loadTierStates(ctx context.Context, tx pgx.Tx, schema, tag string, report Report, asOf time.Time) (map[string]tierState, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT DISTINCT ON (h.scholar_id) h.scholar_id, h.to_tier, h.as_of
+		FROM %s.scholar_tier_history h
+		JOIN %s.audit_runs r ON r.id = h.run_id
+		WHERE h.as_of <= $1 AND h.scholar_id = ANY($2) AND r.run_tag IS NOT DISTINCT FROM $3
+		ORDER BY h.scholar_id, h.as_of DESC, h.created_at DESC`, schema, schema), asOf, reportScholarIDs(report), nullString(tag))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer rows.Close()
+	states := map[string]tierState{}
+	for rows.Next() {
+		var scholarID string
+		var state tierState
+		if err := rows.Scan(&scholarID, &state.Tier, &state.Since); err != nil {
+			return nil, err
+		}
+		states[scholarID] = state
 	}
-	defer file.Close()
+	return states, rows.Err()
+}
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"scholar_id",
-		"program",
-		"last_contact",
-		"first_contact",
-		"next_due_date",
-		"gap_days",
-		"days_past_due",
-		"missed_cadences",
-		"days_since_first_contact",
-		"avg_interval_days",
-		"contacts_per_month",
-		"tier",
-		"last_channel",
-		"last_status",
-		"contact_count",
-	}); err != nil {
-		return err
+// reportCopyTables builds the COPY payload for every table keyed by run_id.
+// The audit_runs row must be inserted first.
+func reportCopyTables(report Report, runID uuid.UUID) []copyTable {
+	scholarRows := make([][]any, 0, len(report.Scholars))
+	for _, entry := range report.Scholars {
+		scholarRows = append(scholarRows, []any{
+			uuid.New(),
+			runID,
+			entry.ScholarID,
+			nullString(entry.Program),
+			nullString(entry.Owner),
+			nullString(entry.LastChannel),
+			nullString(entry.LastStatus),
+			nullDate(entry.EnrollmentDate),
+			nullDate(entry.LastContact),
+			nullDate(entry.LastSuccess),
+			nullDate(entry.FirstContact),
+			nullDate(entry.NextDueDate),
+			nullDate(entry.ScheduledDate),
+			entry.ContactCount,
+			entry.FailedAttempts,
+			entry.SinceSuccess,
+			entry.FailedStreak,
+			entry.GapDays,
+			entry.DaysPastDue,
+			entry.MissedCadences,
+			entry.CadenceDays,
+			entry.DueWindowDays,
+			entry.DaysSinceFirst,
+			entry.AvgIntervalDays,
+			entry.ContactsPerMonth,
+			entry.Tier,
+			tierRankOrZero(report.Tiers, entry.Tier),
+			nullDate(entry.TierSince),
+			nullInt(entry.DaysInTier),
+			entry.RawGapDays,
+			entry.PausedDays,
+		})
+	}
+
+	programRows := make([][]any, 0, len(report.ProgramSummary))
+	for _, entry := range report.ProgramSummary {
+		row := []any{
+			uuid.New(),
+			runID,
+			entry.Program,
+			entry.CadenceDays,
+			entry.DueWindowDays,
+			entry.Scholars,
+			entry.AvgGapDays,
+			entry.AvgMissedCadences,
+		}
+		programRows = append(programRows, append(row, legacyTierColumns(entry.TierCounts, report.Tiers)...))
+	}
+
+	ownerRows := make([][]any, 0, len(report.OwnerSummary))
+	for _, entry := range report.OwnerSummary {
+		ownerRows = append(ownerRows, []any{
+			uuid.New(),
+			runID,
+			entry.Owner,
+			entry.Scholars,
+			entry.AvgGapDays,
+			entry.AvgMissedCadences,
+		})
+	}
+
+	channelRows := make([][]any, 0, len(report.ChannelSummary))
+	for channel, count := range report.ChannelSummary {
+		channelRows = append(channelRows, []any{uuid.New(), runID, channel, count})
+	}
+
+	statusRows := make([][]any, 0, len(report.StatusSummary))
+	for status, count := range report.StatusSummary {
+		statusRows = append(statusRows, []any{uuid.New(), runID, status, count})
+	}
+
+	tierScopes := []tierScope{{scope: "run", counts: report.Summary.TierCounts}}
+	for _, entry := range report.ProgramSummary {
+		tierScopes = append(tierScopes, tierScope{scope: "program", key: entry.Program, counts: entry.TierCounts})
+	}
+	for _, entry := range report.OwnerSummary {
+		tierScopes = append(tierScopes, tierScope{scope: "owner", key: entry.Owner, counts: entry.TierCounts})
+	}
+	tierRows := [][]any{}
+	for _, scope := range tierScopes {
+		for _, entry := range scope.counts {
+			tierRows = append(tierRows, []any{
+				uuid.New(),
+				runID,
+				scope.scope,
+				nullString(scope.key),
+				entry.Tier,
+				tierRankOrZero(report.Tiers, entry.Tier),
+				entry.Count,
+			})
+		}
+	}
+
+	dueRows := make([][]any, 0, len(report.DueSummary))
+	for _, entry := range report.DueSummary {
+		dueRows = append(dueRows, []any{
+			uuid.New(),
+			runID,
+			entry.Label,
+			nullInt(entry.MinDays),
+			nullInt(entry.MaxDays),
+			entry.Count,
+		})
+	}
+
+	recencyRows := make([][]any, 0, len(report.RecencySummary))
+	for _, entry := range report.RecencySummary {
+		recencyRows = append(recencyRows, []any{
+			uuid.New(),
+			runID,
+			entry.Label,
+			nullInt(entry.MinDays),
+			nullInt(entry.MaxDays),
+			entry.Count,
+		})
+	}
+
+	return []copyTable{
+		{
+			table: "audit_scholar_gaps",
+			columns: []string{
+				"id", "run_id", "scholar_id", "program", "owner", "last_channel", "last_status",
+				"enrollment_date", "last_contact", "last_successful_contact", "first_contact", "next_due_date", "scheduled_date", "contact_count",
+				"failed_attempts", "attempts_since_success", "consecutive_failed_attempts", "gap_days", "days_past_due",
+				"missed_cadences", "cadence_days", "due_window_days", "days_since_first_contact", "avg_interval_days", "contacts_per_month", "tier", "tier_rank",
+				"tier_since", "days_in_tier", "raw_gap_days", "paused_days",
+			},
+			rows: scholarRows,
+		},
+		{
+			table: "audit_program_summary",
+			columns: []string{
+				"id", "run_id", "program", "cadence_days", "due_window_days", "scholars", "avg_gap_days", "avg_missed_cadences",
+				"on_track_count", "due_soon_count", "overdue_count", "critical_count", "never_contacted_count",
+			},
+			rows: programRows,
+		},
+		{
+			table:   "audit_owner_summary",
+			columns: []string{"id", "run_id", "owner", "scholars", "avg_gap_days", "avg_missed_cadences"},
+			rows:    ownerRows,
+		},
+		{
+			table:   "audit_channel_summary",
+			columns: []string{"id", "run_id", "channel", "touchpoint_count"},
+			rows:    channelRows,
+		},
+		{
+			table:   "audit_status_summary",
+			columns: []string{"id", "run_id", "status", "touchpoint_count"},
+			rows:    statusRows,
+		},
+		{
+			table:   "audit_tier_counts",
+			columns: []string{"id", "run_id", "scope", "scope_key", "tier", "tier_rank", "scholar_count"},
+			rows:    tierRows,
+		},
+		{
+			table:   "audit_due_summary",
+			columns: []string{"id", "run_id", "label", "min_days", "max_days", "bucket_count"},
+			rows:    dueRows,
+		},
+		{
+			table:   "audit_recency_summary",
+			columns: []string{"id", "run_id", "label", "min_days", "max_days", "bucket_count"},
+			rows:    recencyRows,
+		},
+	}
+}
+
+type copyTable struct {
+	table   string
+	columns []string
+	rows    [][]any
+}
+
+// progressCopySource feeds COPY from memory and reports every `every` rows
+// so long scholar loads show they are still moving.
+type progressCopySource struct {
+	rows     [][]any
+	table    string
+	progress io.Writer
+	every    int
+	index    int
+}
+
+func (source *progressCopySource) Next() bool {
+	if source.index > 0 && source.progress != nil && source.every > 0 && source.index%source.every == 0 && source.index < len(source.rows) {
+		fmt.Fprintf(source.progress, "  %s: %d/%d rows\n", source.table, source.index, len(source.rows))
+	}
+	source.index++
+	return source.index <= len(source.rows)
+}
+
+func (source *progressCopySource) Values() ([]any, error) {
+	return source.rows[source.index-1], nil
+}
+
+func (source *progressCopySource) Err() error {
+	return nil
+}
+
+func runHistory(args []string) error {
+	flags := flag.NewFlagSet("history", flag.ExitOnError)
+	dbSchema := flags.String("db-schema", "touchpoint_gap_audit", "Postgres schema for audit tables")
+	dbTimeout := flags.Duration("db-timeout", defaultDBTimeout, "Timeout for reading run history")
+	tag := flags.String("tag", "", "Only list runs stored with this --db-tag")
+	from := flags.String("from", "", "Only list runs with as-of on or after this date (YYYY-MM-DD)")
+	to := flags.String("to", "", "Only list runs with as-of on or before this date (YYYY-MM-DD)")
+	limit := flags.Int("limit", 20, "Maximum number of most recent runs to list (0 for all)")
+	programs := flags.Bool("programs", false, "Print per-program summaries across runs")
+	jsonOut := flags.String("json", "", "Optional JSON output path for the run history")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter := HistoryFilter{Tag: strings.TrimSpace(*tag), Limit: *limit}
+	if *from != "" {
+		parsed, err := parseDate(*from)
+		if err != nil {
+			return fmt.Errorf("invalid --from date: %w", err)
+		}
+		filter.From = parsed
+	}
+	if *to != "" {
+		parsed, err := parseDate(*to)
+		if err != nil {
+			return fmt.Errorf("invalid --to date: %w", err)
+		}
+		filter.To = parsed
+	}
+	if filter.Limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+
+	dbURL := dbURLFromEnv()
+	if dbURL == "" {
+		return errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL")
+	}
+	runs, err := loadHistoryFromDB(DBConfig{URL: dbURL, Schema: *dbSchema, Timeout: *dbTimeout}, filter)
+	if err != nil {
+		return err
+	}
+
+	printHistory(runs, *programs)
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(runs, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*jsonOut, data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("\nHistory JSON saved to %s\n", *jsonOut)
+	}
+	return nil
+}
+
+// loadHistoryFromDB reads stored runs oldest first. Tier counts come from
+// audit_tier_counts; runs stored before that table existed fall back to the
+// fixed count columns.
+func loadHistoryFromDB(cfg DBConfig, filter HistoryFilter) ([]HistoryRun, error) {
+	if err := requirePostgres(cfg.URL, "history"); err != nil {
+		return nil, err
+	}
+	schema, err := sanitizeSchema(cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("pgx", cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := checkSchemaCurrent(ctx, db, schema); err != nil {
+		return nil, err
+	}
+
+	limit := sql.NullInt64{Int64: int64(filter.Limit), Valid: filter.Limit > 0}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id::text, as_of, COALESCE(run_tag, ''), created_at, total_scholars, avg_gap_days, max_gap_days,
+			COALESCE(on_track_count, 0), COALESCE(due_soon_count, 0), COALESCE(overdue_count, 0),
+			COALESCE(critical_count, 0), never_contacted_count
+		FROM %s.audit_runs
+		WHERE ($1 = '' OR run_tag = $1)
+			AND ($2::date IS NULL OR as_of >= $2)
+			AND ($3::date IS NULL OR as_of <= $3)
+		ORDER BY as_of DESC, created_at DESC
+		LIMIT $4`, schema),
+		filter.Tag,
+		nullDate(filter.From),
+		nullDate(filter.To),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []HistoryRun{}
+	legacy := map[string][]TierCount{}
+	for rows.Next() {
+		var run HistoryRun
+		var asOf time.Time
+		var onTrack, dueSoon, overdue, critical, neverContacted int
+		if err := rows.Scan(&run.RunID, &asOf, &run.Tag, &run.CreatedAt, &run.TotalScholars, &run.AvgGapDays, &run.MaxGapDays,
+			&onTrack, &dueSoon, &overdue, &critical, &neverContacted); err != nil {
+			return nil, err
+		}
+		run.AsOf = formatDate(asOf)
+		legacy[run.RunID] = legacyTierCounts(onTrack, dueSoon, overdue, critical, neverContacted)
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+	// Newest runs win the limit; present them oldest first for trends.
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+
+	runIDs := make([]string, 0, len(runs))
+	byID := map[string]*HistoryRun{}
+	for i := range runs {
+		runIDs = append(runIDs, runs[i].RunID)
+		byID[runs[i].RunID] = &runs[i]
+	}
+
+	programTiers := map[string]map[string][]TierCount{}
+	tierRows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT run_id::text, scope, COALESCE(scope_key, ''), tier, scholar_count
+		FROM %s.audit_tier_counts
+		WHERE run_id::text = ANY($1) AND scope IN ('run', 'program')
+		ORDER BY tier_rank`, schema), runIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer tierRows.Close()
+	for tierRows.Next() {
+		var runID, scope, key, tier string
+		var count int
+		if err := tierRows.Scan(&runID, &scope, &key, &tier, &count); err != nil {
+			return nil, err
+		}
+		entry := TierCount{Tier: tier, Count: count}
+		if scope == "run" {
+			byID[runID].TierCounts = append(byID[runID].TierCounts, entry)
+			continue
+		}
+		if programTiers[runID] == nil {
+			programTiers[runID] = map[string][]TierCount{}
+		}
+		programTiers[runID][key] = append(programTiers[runID][key], entry)
+	}
+	if err := tierRows.Err(); err != nil {
+		return nil, err
+	}
+
+	programRows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT run_id::text, program, COALESCE(cadence_days, 0), COALESCE(due_window_days, 0), scholars, avg_gap_days, avg_missed_cadences,
+			COALESCE(on_track_count, 0), COALESCE(due_soon_count, 0), COALESCE(overdue_count, 0),
+			COALESCE(critical_count, 0), never_contacted_count
+		FROM %s.audit_program_summary
+		WHERE run_id::text = ANY($1)
+		ORDER BY program`, schema), runIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer programRows.Close()
+	for programRows.Next() {
+		var runID string
+		var entry ProgramSummary
+		var onTrack, dueSoon, overdue, critical, neverContacted int
+		if err := programRows.Scan(&runID, &entry.Program, &entry.CadenceDays, &entry.DueWindowDays, &entry.Scholars, &entry.AvgGapDays, &entry.AvgMissedCadences,
+			&onTrack, &dueSoon, &overdue, &critical, &neverContacted); err != nil {
+			return nil, err
+		}
+		entry.TierCounts = programTiers[runID][entry.Program]
+		if len(entry.TierCounts) == 0 {
+			entry.TierCounts = legacyTierCounts(onTrack, dueSoon, overdue, critical, neverContacted)
+		}
+		byID[runID].ProgramSummary = append(byID[runID].ProgramSummary, entry)
+	}
+	if err := programRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range runs {
+		if len(runs[i].TierCounts) == 0 {
+			runs[i].TierCounts = legacy[runs[i].RunID]
+		}
+	}
+	return runs, nil
+}
+
+func legacyTierCounts(onTrack int, dueSoon int, overdue int, critical int, neverContacted int) []TierCount {
+	return []TierCount{
+		{Tier: "on_track", Count: onTrack},
+		{Tier: "due_soon", Count: dueSoon},
+		{Tier: "overdue", Count: overdue},
+		{Tier: "critical", Count: critical},
+		{Tier: tierNeverContacted, Count: neverContacted},
+	}
+}
+
+// historyTiers merges tier names across runs in first-seen order so runs
+// stored with different ladders still line up.
+func historyTiers(runs []HistoryRun) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, run := range runs {
+		for _, entry := range run.TierCounts {
+			if !seen[entry.Tier] {
+				seen[entry.Tier] = true
+				names = append(names, entry.Tier)
+			}
+		}
+	}
+	return names
+}
+
+func printHistory(runs []HistoryRun, programs bool) {
+	fmt.Println("Group Scholar Touchpoint Gap History")
+	fmt.Println("======================================")
+	if len(runs) == 0 {
+		fmt.Println("No stored runs match.")
+		return
+	}
+	fmt.Printf("Runs: %d (%s to %s)\n\n", len(runs), runs[0].AsOf, runs[len(runs)-1].AsOf)
+	for _, run := range runs {
+		tag := run.Tag
+		if tag == "" {
+			tag = "-"
+		}
+		fmt.Printf("%s | %s | %s | scholars %d | gap avg %.1f max %d | %s\n",
+			run.RunID,
+			run.AsOf,
+			tag,
+			run.TotalScholars,
+			run.AvgGapDays,
+			run.MaxGapDays,
+			formatTierCounts(run.TierCounts, false),
+		)
+	}
+
+	first := runs[0]
+	last := runs[len(runs)-1]
+	fmt.Println("\nTrend")
+	fmt.Println("--------------------------------------")
+	for _, tier := range historyTiers(runs) {
+		start := tierCount(first.TierCounts, tier)
+		end := tierCount(last.TierCounts, tier)
+		fmt.Printf("%s: %d -> %d (%+d)\n", tierLabel(tier), start, end, end-start)
+	}
+
+	if !programs {
+		return
+	}
+	fmt.Println("\nPrograms")
+	fmt.Println("--------------------------------------")
+	for _, run := range runs {
+		for _, entry := range run.ProgramSummary {
+			fmt.Printf("%s | %s | scholars %d | gap avg %.1f | %s\n",
+				run.AsOf,
+				entry.Program,
+				entry.Scholars,
+				entry.AvgGapDays,
+				formatTierCounts(entry.TierCounts, false),
+			)
+		}
+	}
+}
+
+func runCompare(args []string) error {
+	flags := flag.NewFlagSet("compare", flag.ExitOnError)
+	base := flags.String("base", "", "Earlier report: stored run ID or JSON report path")
+	current := flags.String("current", "", "Later report: stored run ID or JSON report path")
+	dbSchema := flags.String("db-schema", "touchpoint_gap_audit", "Postgres schema for audit tables")
+	dbTimeout := flags.Duration("db-timeout", defaultDBTimeout, "Timeout for reading each stored run")
+	jsonOut := flags.String("json", "", "Optional JSON output path for the comparison")
+	csvOut := flags.String("csv", "", "Optional CSV output of changed scholars")
+	programsOut := flags.String("programs-csv", "", "Optional CSV output of per-program tier deltas")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *base == "" || *current == "" {
+		return errors.New("--base and --current are required")
+	}
+
+	cfg := DBConfig{URL: dbURLFromEnv(), Schema: *dbSchema, Timeout: *dbTimeout}
+	baseReport, err := loadComparisonReport(*base, cfg)
+	if err != nil {
+		return fmt.Errorf("base: %w", err)
+	}
+	currentReport, err := loadComparisonReport(*current, cfg)
+	if err != nil {
+		return fmt.Errorf("current: %w", err)
+	}
+
+	comparison := compareReports(baseReport, currentReport)
+	comparison.Base.Source = *base
+	comparison.Current.Source = *current
+	printComparison(comparison)
+
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*jsonOut, data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("\nComparison JSON saved to %s\n", *jsonOut)
+	}
+	if *csvOut != "" {
+		if err := writeComparisonCSV(comparison, *csvOut); err != nil {
+			return err
+		}
+		fmt.Printf("Comparison CSV saved to %s\n", *csvOut)
+	}
+	if *programsOut != "" {
+		if err := writeComparisonProgramsCSV(comparison, *programsOut); err != nil {
+			return err
+		}
+		fmt.Printf("Comparison program CSV saved to %s\n", *programsOut)
+	}
+	return nil
+}
+
+// loadComparisonReport reads a JSON report when ref names an existing file
+// and otherwise treats it as a stored run ID.
+func loadComparisonReport(ref string, cfg DBConfig) (Report, error) {
+	if _, err := os.Stat(ref); err == nil {
+		data, err := os.ReadFile(ref)
+		if err != nil {
+			return Report{}, err
+		}
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			return Report{}, fmt.Errorf("%s: %w", ref, err)
+		}
+		return report, nil
+	}
+	if _, err := uuid.Parse(ref); err != nil {
+		return Report{}, fmt.Errorf("%s is neither a JSON report file nor a run ID", ref)
+	}
+	if cfg.URL == "" {
+		return Report{}, errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL")
+	}
+	return loadReportFromDB(cfg, ref)
+}
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	runID := flags.String("run-id", "", "Stored audit run ID to export")
+	dbSchema := flags.String("db-schema", "touchpoint_gap_audit", "Postgres schema for audit tables")
+	dbTimeout := flags.Duration("db-timeout", defaultDBTimeout, "Timeout for loading the stored run")
+	minTier := flags.String("min-tier", "", "Minimum tier for alerts (default: the run's stored --min-tier, else overdue)")
+	jsonOut := flags.String("json", "", "Optional JSON output path")
+	alertsOut := flags.String("alerts", "", "Optional CSV output for alert tiers")
+	programsOut := flags.String("programs-csv", "", "Optional CSV output for program summary")
+	ownersOut := flags.String("owners-csv", "", "Optional CSV output for owner caseload summary")
+	channelsOut := flags.String("channels-csv", "", "Optional CSV output for channel summary")
+	statusesOut := flags.String("statuses-csv", "", "Optional CSV output for last status summary")
+	dueOut := flags.String("due-csv", "", "Optional CSV output for due-date buckets")
+	recencyOut := flags.String("recency-csv", "", "Optional CSV output for recency buckets")
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintln(out, "Usage: export --run-id ID [output flags]")
+		fmt.Fprintln(out, "")
+		fmt.Fprintln(out, "Rebuilds the report of a stored run. The database does not keep row-level rejects,")
+		fmt.Fprintln(out, "passthrough columns, snooze details (snoozed_until, snooze_reason), the snoozed and")
+		fmt.Fprintln(out, "excluded scholar lists, or per-touchpoint source paths, so exports leave them empty.")
+		fmt.Fprintln(out, "Stored runs are read from Postgres; sqlite:// URLs only support storing runs.")
+		fmt.Fprintln(out, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(*runID); err != nil {
+		return fmt.Errorf("--run-id must be a stored run ID: %q", *runID)
+	}
+	dbURL := dbURLFromEnv()
+	if dbURL == "" {
+		return errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL")
+	}
+
+	report, err := loadReportFromDB(DBConfig{URL: dbURL, Schema: *dbSchema, Timeout: *dbTimeout}, *runID)
+	if err != nil {
+		return err
+	}
+	alertTier := *minTier
+	if alertTier == "" {
+		alertTier = report.Summary.MinTier
+	}
+	if alertTier == "" {
+		alertTier = "overdue"
+	}
+	if _, ok := tierRank(report.Tiers, alertTier); !ok {
+		return fmt.Errorf("invalid --min-tier value for this run: %s", alertTier)
+	}
+
+	printReport(report)
+	return writeExports(report, ExportPaths{
+		JSON:     *jsonOut,
+		Alerts:   *alertsOut,
+		MinTier:  alertTier,
+		Programs: *programsOut,
+		Owners:   *ownersOut,
+		Channels: *channelsOut,
+		Statuses: *statusesOut,
+		Due:      *dueOut,
+		Recency:  *recencyOut,
+	})
+}
+
+// loadReportFromDB rehydrates a stored run into a Report. Row-level reject
+// details, passthrough columns, snooze details, the snoozed and excluded
+// scholar lists, and last-source paths are not stored, so those parts of
+// the original report come back empty. The database must be at the latest
+// migration; runs stored before the due summary or tier tables existed
+// fall back to values derived from the scholar rows.
+func loadReportFromDB(cfg DBConfig, runID string) (Report, error) {
+	if err := requirePostgres(cfg.URL, "loading stored runs"); err != nil {
+		return Report{}, err
+	}
+	schema, err := sanitizeSchema(cfg.Schema)
+	if err != nil {
+		return Report{}, err
+	}
+
+	db, err := sql.Open("pgx", cfg.URL)
+	if err != nil {
+		return Report{}, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return Report{}, err
+	}
+	if err := checkSchemaCurrent(ctx, db, schema); err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		ChannelSummary: map[string]int{},
+		StatusSummary:  map[string]int{},
+	}
+	summary := &report.Summary
+	var asOf time.Time
+	var timezone, fingerprint, minTier, toolVersion sql.NullString
+	var topN sql.NullInt64
+	var parameters []byte
+	var inputFiles []string
+	var onTrack, dueSoon, overdue, critical, neverContacted int
+	// database/sql cannot scan Postgres arrays directly; pgtype can.
+	arrays := pgtype.NewMap()
+	err = db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT as_of, cadence_days, due_window_days, total_scholars, avg_gap_days, median_gap_days, max_gap_days,
+			avg_missed_cadences, max_missed_cadences, invalid_rows, future_rows, failed_attempts, schedule_future,
+			input_files, timezone, input_fingerprint, dedupe_day, top_n, min_tier, tool_version,
+			success_statuses, tiers, parameters, business_days,
+			COALESCE(on_track_count, 0), COALESCE(due_soon_count, 0), COALESCE(overdue_count, 0),
+			COALESCE(critical_count, 0), never_contacted_count
+		FROM %s.audit_runs
+		WHERE id = $1`, schema), runID).Scan(
+		&asOf, &summary.CadenceDays, &summary.DueWindowDays, &summary.TotalScholars, &summary.AvgGapDays, &summary.MedianGapDays, &summary.MaxGapDays,
+		&summary.AvgMissedCadences, &summary.MaxMissedCadences, &summary.InvalidRows, &summary.FutureRows, &summary.FailedAttempts, &summary.ScheduleFuture,
+		arrays.SQLScanner(&inputFiles), &timezone, &fingerprint, &summary.DedupeDay, &topN, &minTier, &toolVersion,
+		arrays.SQLScanner(&summary.SuccessStatuses), arrays.SQLScanner(&report.Tiers), &parameters, &summary.BusinessDays,
+		&onTrack, &dueSoon, &overdue, &critical, &neverContacted,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Report{}, fmt.Errorf("run %s not found", runID)
+	}
+	if err != nil {
+		return Report{}, err
+	}
+	summary.AsOf = formatDate(asOf)
+	summary.Timezone = timezone.String
+	summary.InputFingerprint = fingerprint.String
+	summary.TopN = int(topN.Int64)
+	summary.MinTier = minTier.String
+	summary.ToolVersion = toolVersion.String
+	if len(summary.SuccessStatuses) == 0 {
+		summary.SuccessStatuses = nil
+	}
+	if len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &report.Parameters); err != nil {
+			return Report{}, fmt.Errorf("run %s parameters: %w", runID, err)
+		}
+	}
+	summary.PolicyPrograms = report.Parameters.Policy.programCount()
+	for _, path := range inputFiles {
+		report.Sources = append(report.Sources, SourceSummary{Path: path})
+	}
+
+	tierCounts := map[string]map[string][]TierCount{}
+	tierNames := []string{}
+	tierRows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT scope, COALESCE(scope_key, ''), tier, scholar_count
+		FROM %s.audit_tier_counts
+		WHERE run_id = $1
+		ORDER BY tier_rank`, schema), runID)
+	if err != nil {
+		return Report{}, err
+	}
+	defer tierRows.Close()
+	for tierRows.Next() {
+		var scope, key, tier string
+		var count int
+		if err := tierRows.Scan(&scope, &key, &tier, &count); err != nil {
+			return Report{}, err
+		}
+		if tierCounts[scope] == nil {
+			tierCounts[scope] = map[string][]TierCount{}
+		}
+		tierCounts[scope][key] = append(tierCounts[scope][key], TierCount{Tier: tier, Count: count})
+		if scope == "run" {
+			tierNames = append(tierNames, tier)
+		}
+	}
+	if err := tierRows.Err(); err != nil {
+		return Report{}, err
+	}
+	if len(report.Tiers) == 0 {
+		report.Tiers = tierNames
+	}
+	if len(report.Tiers) == 0 {
+		report.Tiers = defaultTierLadder().names()
+	}
+	summary.TierCounts = tierCounts["run"][""]
+	if len(summary.TierCounts) == 0 {
+		summary.TierCounts = legacyTierCounts(onTrack, dueSoon, overdue, critical, neverContacted)
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT scholar_id, COALESCE(program, ''), COALESCE(owner, ''), COALESCE(last_channel, ''), COALESCE(last_status, ''),
+			enrollment_date, last_contact, last_successful_contact, first_contact, next_due_date, scheduled_date, contact_count,
+			failed_attempts, attempts_since_success, consecutive_failed_attempts, gap_days, days_past_due,
+			missed_cadences, COALESCE(cadence_days, 0), COALESCE(due_window_days, 0), days_since_first_contact,
+			avg_interval_days, contacts_per_month, tier, tier_since, days_in_tier,
+			COALESCE(raw_gap_days, gap_days), paused_days
+		FROM %s.audit_scholar_gaps
+		WHERE run_id = $1
+		ORDER BY gap_days DESC, scholar_id`, schema), runID)
+	if err != nil {
+		return Report{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry ScholarSummary
+		var enrollment, lastContact, lastSuccess, firstContact, nextDue, scheduled, tierSince sql.NullTime
+		var daysInTier sql.NullInt64
+		if err := rows.Scan(&entry.ScholarID, &entry.Program, &entry.Owner, &entry.LastChannel, &entry.LastStatus,
+			&enrollment, &lastContact, &lastSuccess, &firstContact, &nextDue, &scheduled, &entry.ContactCount,
+			&entry.FailedAttempts, &entry.SinceSuccess, &entry.FailedStreak, &entry.GapDays, &entry.DaysPastDue,
+			&entry.MissedCadences, &entry.CadenceDays, &entry.DueWindowDays, &entry.DaysSinceFirst,
+			&entry.AvgIntervalDays, &entry.ContactsPerMonth, &entry.Tier, &tierSince, &daysInTier,
+			&entry.RawGapDays, &entry.PausedDays); err != nil {
+			return Report{}, err
+		}
+		entry.EnrollmentDate = enrollment.Time
+		entry.LastContact = lastContact.Time
+		entry.LastSuccess = lastSuccess.Time
+		entry.FirstContact = firstContact.Time
+		entry.NextDueDate = nextDue.Time
+		entry.ScheduledDate = scheduled.Time
+		entry.TierSince = tierSince.Time
+		if daysInTier.Valid {
+			days := int(daysInTier.Int64)
+			entry.DaysInTier = &days
+		}
+		report.Scholars = append(report.Scholars, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return Report{}, err
+	}
+	report.TopGaps = report.Scholars
+	if summary.TopN > 0 && len(report.TopGaps) > summary.TopN {
+		report.TopGaps = report.TopGaps[:summary.TopN]
+	}
+
+	programRows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT program, COALESCE(cadence_days, 0), COALESCE(due_window_days, 0), scholars, avg_gap_days, avg_missed_cadences,
+			COALESCE(on_track_count, 0), COALESCE(due_soon_count, 0), COALESCE(overdue_count, 0),
+			COALESCE(critical_count, 0), never_contacted_count
+		FROM %s.audit_program_summary
+		WHERE run_id = $1
+		ORDER BY program`, schema), runID)
+	if err != nil {
+		return Report{}, err
+	}
+	defer programRows.Close()
+	for programRows.Next() {
+		var entry ProgramSummary
+		if err := programRows.Scan(&entry.Program, &entry.CadenceDays, &entry.DueWindowDays, &entry.Scholars, &entry.AvgGapDays, &entry.AvgMissedCadences,
+			&onTrack, &dueSoon, &overdue, &critical, &neverContacted); err != nil {
+			return Report{}, err
+		}
+		entry.TierCounts = tierCounts["program"][entry.Program]
+		if len(entry.TierCounts) == 0 {
+			entry.TierCounts = legacyTierCounts(onTrack, dueSoon, overdue, critical, neverContacted)
+		}
+		report.ProgramSummary = append(report.ProgramSummary, entry)
+	}
+	if err := programRows.Err(); err != nil {
+		return Report{}, err
+	}
+	sort.SliceStable(report.ProgramSummary, func(i, j int) bool {
+		return moreSevere(report.ProgramSummary[i].TierCounts, report.ProgramSummary[j].TierCounts)
+	})
+
+	ownerRows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT owner, scholars, avg_gap_days, avg_missed_cadences
+		FROM %s.audit_owner_summary
+		WHERE run_id = $1
+		ORDER BY owner`, schema), runID)
+	if err != nil {
+		return Report{}, err
+	}
+	defer ownerRows.Close()
+	for ownerRows.Next() {
+		var entry OwnerSummary
+		if err := ownerRows.Scan(&entry.Owner, &entry.Scholars, &entry.AvgGapDays, &entry.AvgMissedCadences); err != nil {
+			return Report{}, err
+		}
+		entry.TierCounts = tierCounts["owner"][entry.Owner]
+		report.OwnerSummary = append(report.OwnerSummary, entry)
+	}
+	if err := ownerRows.Err(); err != nil {
+		return Report{}, err
+	}
+	sort.SliceStable(report.OwnerSummary, func(i, j int) bool {
+		return moreSevere(report.OwnerSummary[i].TierCounts, report.OwnerSummary[j].TierCounts)
+	})
+
+	for table, counts := range map[string]map[string]int{
+		"audit_channel_summary": report.ChannelSummary,
+		"audit_status_summary":  report.StatusSummary,
+	} {
+		keyColumn := "channel"
+		if table == "audit_status_summary" {
+			keyColumn = "status"
+		}
+		countRows, err := db.QueryContext(ctx, fmt.Sprintf(`SELECT %s, touchpoint_count FROM %s.%s WHERE run_id = $1`, keyColumn, schema, table), runID)
+		if err != nil {
+			return Report{}, err
+		}
+		for countRows.Next() {
+			var key string
+			var count int
+			if err := countRows.Scan(&key, &count); err != nil {
+				countRows.Close()
+				return Report{}, err
+			}
+			counts[key] = count
+		}
+		err = countRows.Err()
+		countRows.Close()
+		if err != nil {
+			return Report{}, err
+		}
+	}
+
+	dueBuckets, err := loadBucketRows(ctx, db, schema, "audit_due_summary", runID)
+	if err != nil {
+		return Report{}, err
+	}
+	for _, bucket := range dueBuckets {
+		report.DueSummary = append(report.DueSummary, DueBucketSummary(bucket))
+	}
+	if len(report.DueSummary) == 0 {
+		report.DueSummary = buildDueSummary(report.Scholars, asOf, cadenceClock{})
+	}
+	recencyBuckets, err := loadBucketRows(ctx, db, schema, "audit_recency_summary", runID)
+	if err != nil {
+		return Report{}, err
+	}
+	report.RecencySummary = recencyBuckets
+	if len(report.RecencySummary) == 0 {
+		report.RecencySummary = buildRecencySummary(report.Scholars)
+	}
+	return report, nil
+}
+
+// loadBucketRows reads a due or recency bucket table in bucket order:
+// open-ended low buckets first and the unbounded "unknown" bucket last.
+func loadBucketRows(ctx context.Context, db *sql.DB, schema string, table string, runID string) ([]RecencyBucket, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT label, min_days, max_days, bucket_count
+		FROM %s.%s
+		WHERE run_id = $1
+		ORDER BY COALESCE(min_days, CASE WHEN max_days IS NULL THEN 2147483647 ELSE -2147483648 END)`, schema, table), runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	buckets := []RecencyBucket{}
+	for rows.Next() {
+		var bucket RecencyBucket
+		var minDays, maxDays sql.NullInt64
+		if err := rows.Scan(&bucket.Label, &minDays, &maxDays, &bucket.Count); err != nil {
+			return nil, err
+		}
+		if minDays.Valid {
+			bucket.MinDays = intPtr(int(minDays.Int64))
+		}
+		if maxDays.Valid {
+			bucket.MaxDays = intPtr(int(maxDays.Int64))
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, rows.Err()
+}
+
+// compareReports classifies every scholar by how their tier moved between
+// two reports. A scholar whose tier name did not change is unchanged; other
+// moves are ranked against one ladder merged from both sides, so a higher
+// rank always means a more severe tier. Tiers missing from both ladders are
+// listed in UnknownTiers and their moves reported as unranked.
+func compareReports(base Report, current Report) Comparison {
+	// JSON reports written before the tier ladder was configurable carry
+	// no tiers; they used the default ladder, as the DB loader assumes.
+	if len(base.Tiers) == 0 {
+		base.Tiers = defaultTierLadder().names()
+	}
+	if len(current.Tiers) == 0 {
+		current.Tiers = defaultTierLadder().names()
+	}
+	comparison := Comparison{
+		Base:         ComparisonSide{AsOf: base.Summary.AsOf, Scholars: len(base.Scholars)},
+		Current:      ComparisonSide{AsOf: current.Summary.AsOf, Scholars: len(current.Scholars)},
+		Tiers:        mergeTierNames(current.Tiers, base.Tiers),
+		ChangeCounts: map[string]int{changeWorsened: 0, changeImproved: 0, changeNew: 0, changeRemoved: 0},
+	}
+
+	baseByID := map[string]ScholarSummary{}
+	for _, entry := range base.Scholars {
+		baseByID[entry.ScholarID] = entry
+	}
+	currentByID := map[string]ScholarSummary{}
+	for _, entry := range current.Scholars {
+		currentByID[entry.ScholarID] = entry
+	}
+	unknown := map[string]bool{}
+	for _, entry := range append(append([]ScholarSummary{}, base.Scholars...), current.Scholars...) {
+		if _, ok := tierRank(comparison.Tiers, entry.Tier); !ok {
+			unknown[entry.Tier] = true
+		}
+	}
+	comparison.UnknownTiers = sortedKeys(unknown)
+
+	programs := map[string]*ProgramDelta{}
+	programDelta := func(entry ScholarSummary) *ProgramDelta {
+		key := entry.Program
+		if key == "" {
+			key = "Unassigned"
+		}
+		delta, ok := programs[key]
+		if !ok {
+			delta = &ProgramDelta{Program: key}
+			programs[key] = delta
+		}
+		return delta
+	}
+	baseCounts := map[string]map[string]int{}
+	currentCounts := map[string]map[string]int{}
+	countTier := func(counts map[string]map[string]int, program string, tier string) {
+		if counts[program] == nil {
+			counts[program] = map[string]int{}
+		}
+		counts[program][tier]++
+	}
+
+	for _, entry := range base.Scholars {
+		delta := programDelta(entry)
+		delta.BaseScholars++
+		countTier(baseCounts, delta.Program, entry.Tier)
+		if _, ok := currentByID[entry.ScholarID]; !ok {
+			delta.Removed++
+			comparison.Changes = append(comparison.Changes, ScholarChange{
+				ScholarID:   entry.ScholarID,
+				Program:     entry.Program,
+				Owner:       entry.Owner,
+				Change:      changeRemoved,
+				FromTier:    entry.Tier,
+				FromGapDays: entry.GapDays,
+			})
+		}
+	}
+	for _, entry := range current.Scholars {
+		delta := programDelta(entry)
+		delta.CurrentScholars++
+		countTier(currentCounts, delta.Program, entry.Tier)
+		previous, ok := baseByID[entry.ScholarID]
+		if !ok {
+			delta.New++
+			comparison.Changes = append(comparison.Changes, ScholarChange{
+				ScholarID: entry.ScholarID,
+				Program:   entry.Program,
+				Owner:     entry.Owner,
+				Change:    changeNew,
+				ToTier:    entry.Tier,
+				ToGapDays: entry.GapDays,
+			})
+			continue
+		}
+		if entry.Tier == previous.Tier {
+			continue
+		}
+		fromRank, fromKnown := tierRank(comparison.Tiers, previous.Tier)
+		toRank, toKnown := tierRank(comparison.Tiers, entry.Tier)
+		rankChange := toRank - fromRank
+		change := changeWorsened
+		switch {
+		case !fromKnown || !toKnown:
+			change = changeUnranked
+			rankChange = 0
+		case rankChange == 0:
+			continue
+		case rankChange < 0:
+			change = changeImproved
+			delta.Improved++
+		default:
+			delta.Worsened++
+		}
+		comparison.Changes = append(comparison.Changes, ScholarChange{
+			ScholarID:      entry.ScholarID,
+			Program:        entry.Program,
+			Owner:          entry.Owner,
+			Change:         change,
+			FromTier:       previous.Tier,
+			ToTier:         entry.Tier,
+			FromGapDays:    previous.GapDays,
+			ToGapDays:      entry.GapDays,
+			TierRankChange: rankChange,
+		})
+	}
+	for _, change := range comparison.Changes {
+		comparison.ChangeCounts[change.Change]++
+	}
+
+	changeOrder := map[string]int{changeWorsened: 0, changeNew: 1, changeImproved: 2, changeUnranked: 3, changeRemoved: 4}
+	sort.Slice(comparison.Changes, func(i, j int) bool {
+		a, b := comparison.Changes[i], comparison.Changes[j]
+		if changeOrder[a.Change] != changeOrder[b.Change] {
+			return changeOrder[a.Change] < changeOrder[b.Change]
+		}
+		if a.TierRankChange != b.TierRankChange {
+			if a.Change == changeImproved {
+				return a.TierRankChange < b.TierRankChange
+			}
+			return a.TierRankChange > b.TierRankChange
+		}
+		return a.ScholarID < b.ScholarID
+	})
+
+	totalBase := map[string]int{}
+	totalCurrent := map[string]int{}
+	for _, entry := range base.Scholars {
+		totalBase[entry.Tier]++
+	}
+	for _, entry := range current.Scholars {
+		totalCurrent[entry.Tier]++
+	}
+	comparison.TierDeltas = tierDeltas(comparison.Tiers, totalBase, totalCurrent)
+
+	keys := make([]string, 0, len(programs))
+	for key := range programs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		delta := programs[key]
+		delta.TierDeltas = tierDeltas(comparison.Tiers, baseCounts[key], currentCounts[key])
+		comparison.Programs = append(comparison.Programs, *delta)
+	}
+	return comparison
+}
+
+// mergeTierNames keeps the primary ladder order and slots each tier that
+// only the other ladder uses in right after the nearest tier it follows
+// there, so scheduled stays next to the first tier and never_contacted
+// stays last.
+func mergeTierNames(primary []string, other []string) []string {
+	names := append([]string{}, primary...)
+	insertAt := 0
+	for _, name := range other {
+		if rank, ok := tierRank(names, name); ok {
+			insertAt = rank + 1
+			continue
+		}
+		names = append(names[:insertAt], append([]string{name}, names[insertAt:]...)...)
+		insertAt++
+	}
+	return names
+}
+
+func tierDeltas(tierNames []string, base map[string]int, current map[string]int) []TierDelta {
+	deltas := make([]TierDelta, 0, len(tierNames))
+	for _, tier := range tierNames {
+		deltas = append(deltas, TierDelta{
+			Tier:    tier,
+			Base:    base[tier],
+			Current: current[tier],
+			Delta:   current[tier] - base[tier],
+		})
+	}
+	return deltas
+}
+
+func formatTierDeltas(deltas []TierDelta) string {
+	parts := make([]string, 0, len(deltas))
+	for _, entry := range deltas {
+		if entry.Base == 0 && entry.Current == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %d -> %d (%+d)", strings.ReplaceAll(entry.Tier, "_", " "), entry.Base, entry.Current, entry.Delta))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func printComparison(comparison Comparison) {
+	fmt.Println("Group Scholar Touchpoint Gap Comparison")
+	fmt.Println("======================================")
+	fmt.Printf("Base: %s (as of %s, %d scholars)\n", comparison.Base.Source, comparison.Base.AsOf, comparison.Base.Scholars)
+	fmt.Printf("Current: %s (as of %s, %d scholars)\n", comparison.Current.Source, comparison.Current.AsOf, comparison.Current.Scholars)
+	fmt.Printf("Changes: worsened %d | improved %d | new %d | removed %d\n",
+		comparison.ChangeCounts[changeWorsened],
+		comparison.ChangeCounts[changeImproved],
+		comparison.ChangeCounts[changeNew],
+		comparison.ChangeCounts[changeRemoved],
+	)
+	if len(comparison.UnknownTiers) > 0 {
+		fmt.Printf("Unknown tiers (not in either ladder): %s | unranked changes %d\n",
+			strings.Join(comparison.UnknownTiers, ", "), comparison.ChangeCounts[changeUnranked])
+	}
+	fmt.Printf("Tiers: %s\n", formatTierDeltas(comparison.TierDeltas))
+
+	fmt.Println("\nPrograms")
+	fmt.Println("--------------------------------------")
+	for _, entry := range comparison.Programs {
+		fmt.Printf("%s | scholars %d -> %d | worsened %d | improved %d | new %d | removed %d | %s\n",
+			entry.Program,
+			entry.BaseScholars,
+			entry.CurrentScholars,
+			entry.Worsened,
+			entry.Improved,
+			entry.New,
+			entry.Removed,
+			formatTierDeltas(entry.TierDeltas),
+		)
+	}
+
+	fmt.Println("\nScholar changes")
+	fmt.Println("--------------------------------------")
+	if len(comparison.Changes) == 0 {
+		fmt.Println("No tier changes.")
+		return
+	}
+	for _, change := range comparison.Changes {
+		program := change.Program
+		if program == "" {
+			program = "Unassigned"
+		}
+		fromTier := change.FromTier
+		if fromTier == "" {
+			fromTier = "-"
+		}
+		toTier := change.ToTier
+		if toTier == "" {
+			toTier = "-"
+		}
+		fmt.Printf("%s | %s | %s | %s -> %s | gap %d -> %d days\n",
+			change.ScholarID,
+			program,
+			change.Change,
+			fromTier,
+			toTier,
+			change.FromGapDays,
+			change.ToGapDays,
+		)
+	}
+}
+
+func writeComparisonCSV(comparison Comparison, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"scholar_id",
+		"program",
+		"owner",
+		"change",
+		"from_tier",
+		"to_tier",
+		"from_gap_days",
+		"to_gap_days",
+		"tier_rank_change",
+	}); err != nil {
+		return err
+	}
+
+	for _, change := range comparison.Changes {
+		record := []string{
+			change.ScholarID,
+			change.Program,
+			change.Owner,
+			change.Change,
+			change.FromTier,
+			change.ToTier,
+			fmt.Sprintf("%d", change.FromGapDays),
+			fmt.Sprintf("%d", change.ToGapDays),
+			fmt.Sprintf("%d", change.TierRankChange),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeComparisonProgramsCSV(comparison Comparison, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"program",
+		"base_scholars",
+		"current_scholars",
+		"worsened",
+		"improved",
+		"new",
+		"removed",
+	}
+	for _, tier := range comparison.Tiers {
+		header = append(header, tier+"_delta")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range comparison.Programs {
+		record := []string{
+			entry.Program,
+			fmt.Sprintf("%d", entry.BaseScholars),
+			fmt.Sprintf("%d", entry.CurrentScholars),
+			fmt.Sprintf("%d", entry.Worsened),
+			fmt.Sprintf("%d", entry.Improved),
+			fmt.Sprintf("%d", entry.New),
+			fmt.Sprintf("%d", entry.Removed),
+		}
+		for _, delta := range entry.TierDeltas {
+			record = append(record, fmt.Sprintf("%d", delta.Delta))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type tierScope struct {
+	scope  string
+	key    string
+	counts []TierCount
+}
+
+// Migration is one numbered schema change embedded from migrations/. The SQL
+// refers to the target schema as {{schema}}.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+	Known     bool
+}
+
+const (
+	postgresMigrations = "migrations"
+	sqliteMigrations   = "migrations/sqlite"
+)
+
+func loadMigrations(dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+	migrations := []Migration{}
+	seen := map[int]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".sql" {
+			continue
+		}
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a positive version number", name)
+		}
+		if previous, exists := seen[version]; exists {
+			return nil, fmt.Errorf("migration %s: version %d already used by %s", name, version, previous)
+		}
+		seen[version] = name
+		data, err := migrationFiles.ReadFile(dir + "/" + name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(name, ".sql"),
+			SQL:     string(data),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func latestMigrationVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func (migration Migration) render(schema string) string {
+	return strings.ReplaceAll(migration.SQL, "{{schema}}", schema)
+}
+
+// ensureSchema brings the schema up to the newest embedded migration. It
+// refuses to continue when the database was migrated by a newer binary so
+// older builds never write rows missing columns they do not know about.
+func ensureSchema(ctx context.Context, db *sql.DB, schema string) error {
+	migrations, err := loadMigrations(postgresMigrations)
+	if err != nil {
+		return err
+	}
+	if err := ensureMigrationTable(ctx, db, schema); err != nil {
+		return err
+	}
+	current, err := schemaVersion(ctx, db, schema)
+	if err != nil {
+		return err
+	}
+	if latest := latestMigrationVersion(migrations); current > latest {
+		return fmt.Errorf("database schema %s is at version %d but this binary only knows up to %d; upgrade the binary", schema, current, latest)
+	}
+	_, err = applyMigrations(ctx, db, schema, migrations)
+	return err
+}
+
+// checkSchemaCurrent is the read-only counterpart of ensureSchema. Read
+// commands never apply migrations or take their locks, so they stop with a
+// hint when the schema is missing or behind this binary.
+func checkSchemaCurrent(ctx context.Context, db *sql.DB, schema string) error {
+	migrations, err := loadMigrations(postgresMigrations)
+	if err != nil {
+		return err
+	}
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, schema+".schema_migrations").Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("database schema %s has no migrations applied; run `migrate up --db-schema %s` first", schema, schema)
+	}
+	current, err := schemaVersion(ctx, db, schema)
+	if err != nil {
+		return err
+	}
+	if latest := latestMigrationVersion(migrations); current < latest {
+		return fmt.Errorf("database schema %s is at version %d but this binary expects %d; run `migrate up --db-schema %s` first", schema, current, latest, schema)
+	}
+	return nil
+}
+
+func ensureMigrationTable(ctx context.Context, db *sql.DB, schema string) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, schema)); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s.schema_migrations (
+			version integer PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`, schema))
+	return err
+}
+
+func schemaVersion(ctx context.Context, db *sql.DB, schema string) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COALESCE(MAX(version), 0) FROM %s.schema_migrations`, schema)).Scan(&version)
+	return version, err
+}
+
+// applyMigrations runs each pending migration in its own transaction under
+// an advisory lock, so concurrent runs against a fresh database apply every
+// version exactly once.
+func applyMigrations(ctx context.Context, db *sql.DB, schema string, migrations []Migration) ([]Migration, error) {
+	applied := []Migration{}
+	for _, migration := range migrations {
+		ran, err := applyMigration(ctx, db, schema, migration)
+		if err != nil {
+			return applied, fmt.Errorf("migration %s: %w", migration.Name, err)
+		}
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, schema string, migration Migration) (ran bool, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil || !ran {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, schema+".schema_migrations"); err != nil {
+		return false, err
+	}
+	var exists bool
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s.schema_migrations WHERE version = $1)`, schema), migration.Version).Scan(&exists)
+	if err != nil || exists {
+		return false, err
+	}
+	if _, err = tx.ExecContext(ctx, migration.render(schema)); err != nil {
+		return false, err
+	}
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s.schema_migrations (version, name) VALUES ($1, $2)`, schema), migration.Version, migration.Name); err != nil {
+		return false, err
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// migrationStatuses lists every known migration plus any applied versions
+// this binary does not ship.
+func migrationStatuses(migrations []Migration, applied map[int]MigrationStatus) []MigrationStatus {
+	statuses := []MigrationStatus{}
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, Known: true}
+		if entry, ok := applied[migration.Version]; ok {
+			status.AppliedAt = entry.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for version, entry := range applied {
+		if _, ok := findMigration(migrations, version); !ok {
+			statuses = append(statuses, entry)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
+
+func findMigration(migrations []Migration, version int) (Migration, bool) {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dbSchema := flags.String("db-schema", "touchpoint_gap_audit", "Postgres schema for audit tables")
+	dbTimeout := flags.Duration("db-timeout", defaultDBTimeout, "Timeout for applying migrations")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	action := "status"
+	if flags.NArg() > 0 {
+		action = flags.Arg(0)
+	}
+	if action != "up" && action != "status" {
+		return fmt.Errorf("unknown migrate action %q (use up or status)", action)
+	}
+
+	migrations, err := loadMigrations(postgresMigrations)
+	if err != nil {
+		return err
+	}
+	schema, err := sanitizeSchema(*dbSchema)
+	if err != nil {
+		return err
+	}
+	dbURL := dbURLFromEnv()
+	if dbURL == "" {
+		return errors.New("database URL missing; set TOUCHPOINT_GAP_AUDIT_DB_URL or DATABASE_URL")
+	}
+	if err := requirePostgres(dbURL, "migrate"); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("pgx", dbURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *dbTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	if err := ensureMigrationTable(ctx, db, schema); err != nil {
+		return err
+	}
+
+	if action == "up" {
+		current, err := schemaVersion(ctx, db, schema)
+		if err != nil {
+			return err
+		}
+		if latest := latestMigrationVersion(migrations); current > latest {
+			return fmt.Errorf("database schema %s is at version %d but this binary only knows up to %d; upgrade the binary", schema, current, latest)
+		}
+		applied, err := applyMigrations(ctx, db, schema, migrations)
+		for _, migration := range applied {
+			fmt.Printf("Applied %s\n", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema already up to date.")
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`SELECT version, name, applied_at FROM %s.schema_migrations`, schema))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	applied := map[int]MigrationStatus{}
+	for rows.Next() {
+		var entry MigrationStatus
+		if err := rows.Scan(&entry.Version, &entry.Name, &entry.AppliedAt); err != nil {
+			return err
+		}
+		applied[entry.Version] = entry
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Schema %s migrations (binary knows up to %d)\n", schema, latestMigrationVersion(migrations))
+	for _, status := range migrationStatuses(migrations, applied) {
+		state := "pending"
+		if !status.AppliedAt.IsZero() {
+			state = "applied " + status.AppliedAt.Format(time.RFC3339)
+		}
+		if !status.Known {
+			state += " (unknown to this binary)"
+		}
+		fmt.Printf("%04d %s | %s\n", status.Version, status.Name, state)
+	}
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	if strings.TrimSpace(value) == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
+func nullDate(value time.Time) sql.NullTime {
+	if value.IsZero() {
+		return sql.NullTime{}
+	}
+	year, month, day := value.Date()
+	return sql.NullTime{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func nullInt(value *int) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*value), Valid: true}
+}
+
+// AlertState is the alert history carried between runs: each scholar's tier
+// at the last run and the date they were last alerted.
+type AlertState struct {
+	AsOf     string                     `json:"as_of"`
+	MinTier  string                     `json:"min_tier"`
+	Scholars map[string]AlertStateEntry `json:"scholars"`
+}
+
+type AlertStateEntry struct {
+	Tier      string `json:"tier"`
+	AlertedOn string `json:"alerted_on,omitempty"`
+}
+
+type AlertChanges struct {
+	New       int `json:"new"`
+	Escalated int `json:"escalated"`
+	Unchanged int `json:"unchanged"`
+	Cooldown  int `json:"cooldown"`
+}
+
+// loadAlertState reads the state file; a missing file is an empty state so
+// the first run alerts everyone at or above the threshold.
+func loadAlertState(path string) (AlertState, error) {
+	state := AlertState{Scholars: map[string]AlertStateEntry{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("alert state %s: %w", path, err)
+	}
+	if state.Scholars == nil {
+		state.Scholars = map[string]AlertStateEntry{}
+	}
+	return state, nil
+}
+
+// writeAlertState replaces the state file via a rename so an interrupted
+// run never leaves it half written.
+func writeAlertState(state AlertState, path string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// planAlerts decides which scholars at or above minTier to alert on: those
+// new to the alert tiers and those who escalated since the previous run,
+// unless they were alerted within cooldownDays. A scholar held back by the
+// cooldown keeps their last alerted tier in the state, so the change still
+// fires once the cooldown ends. Scholars below the threshold the state was
+// saved with count as new, so lowering --min-tier alerts the tiers it adds.
+// It returns the reasons keyed by scholar and the state to save for the
+// next run.
+func planAlerts(report Report, state AlertState, minTier string, cooldownDays int) (map[string]string, AlertChanges, AlertState, error) {
+	threshold, ok := tierRank(report.Tiers, minTier)
+	if !ok {
+		return nil, AlertChanges{}, state, fmt.Errorf("invalid --min-tier value: %s", minTier)
+	}
+	asOf, err := parseDate(report.Summary.AsOf)
+	if err != nil {
+		return nil, AlertChanges{}, state, err
+	}
+	if state.AsOf != "" {
+		previous, err := parseDate(state.AsOf)
+		if err != nil {
+			return nil, AlertChanges{}, state, fmt.Errorf("alert state as_of: %w", err)
+		}
+		if previous.After(asOf) {
+			return nil, AlertChanges{}, state, fmt.Errorf("alert state is from %s, after --as-of %s", state.AsOf, report.Summary.AsOf)
+		}
+	}
+
+	previousThreshold := threshold
+	if state.MinTier != "" {
+		if rank, ok := tierRank(report.Tiers, state.MinTier); ok {
+			previousThreshold = rank
+		}
+	}
+
+	reasons := map[string]string{}
+	changes := AlertChanges{}
+	next := AlertState{AsOf: report.Summary.AsOf, MinTier: minTier, Scholars: make(map[string]AlertStateEntry, len(report.Scholars))}
+	for _, entry := range report.Scholars {
+		previous, seen := state.Scholars[entry.ScholarID]
+		current := AlertStateEntry{Tier: entry.Tier, AlertedOn: previous.AlertedOn}
+		rank, known := tierRank(report.Tiers, entry.Tier)
+		if known && rank >= threshold {
+			previousRank, previousKnown := tierRank(report.Tiers, previous.Tier)
+			reason := ""
+			switch {
+			case !seen || !previousKnown || previousRank < threshold || previousRank < previousThreshold:
+				reason = alertReasonNew
+			case rank > previousRank:
+				reason = alertReasonEscalated
+			}
+			switch {
+			case reason == "":
+				changes.Unchanged++
+			case inCooldown(previous.AlertedOn, asOf, cooldownDays):
+				changes.Cooldown++
+				current.Tier = previous.Tier
+			default:
+				if reason == alertReasonNew {
+					changes.New++
+				} else {
+					changes.Escalated++
+				}
+				reasons[entry.ScholarID] = reason
+				current.AlertedOn = report.Summary.AsOf
+			}
+		}
+		next.Scholars[entry.ScholarID] = current
+	}
+	return reasons, changes, next, nil
+}
+
+func inCooldown(alertedOn string, asOf time.Time, cooldownDays int) bool {
+	if alertedOn == "" || cooldownDays <= 0 {
+		return false
+	}
+	alerted, err := parseDate(alertedOn)
+	return err == nil && daysBetween(alerted, asOf) < cooldownDays
+}
+
+// onlyAlerted narrows a report to the scholars chosen by planAlerts.
+func onlyAlerted(report Report, reasons map[string]string) Report {
+	alerted := []ScholarSummary{}
+	for _, entry := range report.Scholars {
+		if reason, ok := reasons[entry.ScholarID]; ok {
+			entry.AlertReason = reason
+			alerted = append(alerted, entry)
+		}
+	}
+	report.Scholars = alerted
+	return report
+}
+
+func writeAlertsCSV(report Report, path string, minTier string) error {
+	threshold, ok := tierRank(report.Tiers, minTier)
+	if !ok {
+		return fmt.Errorf("invalid --min-tier value: %s", minTier)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"scholar_id",
+		"program",
+		"owner",
+		"enrollment_date",
+		"last_contact",
+		"last_successful_contact",
+		"first_contact",
+		"next_due_date",
+		"scheduled_date",
+		"gap_days",
+		"raw_gap_days",
+		"paused_days",
+		"days_past_due",
+		"missed_cadences",
+		"cadence_days",
+		"due_window_days",
+		"days_since_first_contact",
+		"avg_interval_days",
+		"contacts_per_month",
+		"tier",
+		"last_channel",
+		"last_status",
+		"contact_count",
+		"failed_attempts",
+		"attempts_since_success",
+		"consecutive_failed_attempts",
+	}
+	// Snooze columns only mean something when an acks file was loaded.
+	withAcks := report.Summary.SnoozeMode != ""
+	if withAcks {
+		header = append(header, "snoozed_until", "snooze_reason", "acked_by")
+	}
+	header = append(header, "alert_reason")
+	header = append(header, report.ExtraFields...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range report.Scholars {
+		rank, known := tierRank(report.Tiers, entry.Tier)
+		if !known || rank < threshold {
+			continue
+		}
+		record := []string{
+			entry.ScholarID,
+			entry.Program,
+			entry.Owner,
+			formatDate(entry.EnrollmentDate),
+			formatDate(entry.LastContact),
+			formatDate(entry.LastSuccess),
+			formatDate(entry.FirstContact),
+			formatDate(entry.NextDueDate),
+			formatDate(entry.ScheduledDate),
+			fmt.Sprintf("%d", entry.GapDays),
+			fmt.Sprintf("%d", entry.RawGapDays),
+			fmt.Sprintf("%d", entry.PausedDays),
+			fmt.Sprintf("%d", entry.DaysPastDue),
+			fmt.Sprintf("%d", entry.MissedCadences),
+			fmt.Sprintf("%d", entry.CadenceDays),
+			fmt.Sprintf("%d", entry.DueWindowDays),
+			fmt.Sprintf("%d", entry.DaysSinceFirst),
+			fmt.Sprintf("%.1f", entry.AvgIntervalDays),
+			fmt.Sprintf("%.1f", entry.ContactsPerMonth),
+			entry.Tier,
+			entry.LastChannel,
+			entry.LastStatus,
+			fmt.Sprintf("%d", entry.ContactCount),
+			fmt.Sprintf("%d", entry.FailedAttempts),
+			fmt.Sprintf("%d", entry.SinceSuccess),
+			fmt.Sprintf("%d", entry.FailedStreak),
+		}
+		if withAcks {
+			record = append(record, formatDate(entry.SnoozedUntil), entry.SnoozeReason, entry.AckedBy)
+		}
+		record = append(record, entry.AlertReason)
+		for _, name := range report.ExtraFields {
+			record = append(record, entry.Extra[name])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeProgramCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"program",
+		"cadence_days",
+		"due_window_days",
+		"scholars",
+		"avg_gap_days",
+		"avg_missed_cadences",
+	}
+	header = append(header, report.Tiers...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range report.ProgramSummary {
+		record := []string{
+			entry.Program,
+			fmt.Sprintf("%d", entry.CadenceDays),
+			fmt.Sprintf("%d", entry.DueWindowDays),
+			fmt.Sprintf("%d", entry.Scholars),
+			fmt.Sprintf("%.1f", entry.AvgGapDays),
+			fmt.Sprintf("%.1f", entry.AvgMissedCadences),
+		}
+		for _, tier := range report.Tiers {
+			record = append(record, fmt.Sprintf("%d", tierCount(entry.TierCounts, tier)))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeOwnerCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"owner",
+		"scholars",
+		"avg_gap_days",
+		"avg_missed_cadences",
+	}
+	header = append(header, report.Tiers...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range report.OwnerSummary {
+		record := []string{
+			entry.Owner,
+			fmt.Sprintf("%d", entry.Scholars),
+			fmt.Sprintf("%.1f", entry.AvgGapDays),
+			fmt.Sprintf("%.1f", entry.AvgMissedCadences),
+		}
+		for _, tier := range report.Tiers {
+			record = append(record, fmt.Sprintf("%d", tierCount(entry.TierCounts, tier)))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeChannelCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"channel",
+		"touchpoint_count",
+	}); err != nil {
+		return err
+	}
+
+	channels := make([]string, 0, len(report.ChannelSummary))
+	for channel := range report.ChannelSummary {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	for _, channel := range channels {
+		record := []string{
+			channel,
+			fmt.Sprintf("%d", report.ChannelSummary[channel]),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeStatusCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"status",
+		"touchpoint_count",
+	}); err != nil {
+		return err
+	}
+
+	statuses := make([]string, 0, len(report.StatusSummary))
+	for status := range report.StatusSummary {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		record := []string{
+			status,
+			fmt.Sprintf("%d", report.StatusSummary[status]),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeRejectsCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"source",
+		"line",
+		"reason",
+		"scholar_id",
+		"contact_date",
+		"raw_record",
+	}); err != nil {
+		return err
+	}
+
+	for _, entry := range report.Rejects {
+		raw, err := encodeRecord(entry.Raw)
+		if err != nil {
+			return err
+		}
+		record := []string{
+			entry.Source,
+			fmt.Sprintf("%d", entry.Line),
+			entry.Reason,
+			entry.ScholarID,
+			entry.ContactDate,
+			raw,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func encodeRecord(record []string) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.Write(record); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(builder.String(), "\n"), nil
+}
+
+func writeDueCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"label",
+		"min_days",
+		"max_days",
+		"count",
+	}); err != nil {
+		return err
+	}
+
+	for _, entry := range report.DueSummary {
+		record := []string{
+			entry.Label,
+			formatOptionalInt(entry.MinDays),
+			formatOptionalInt(entry.MaxDays),
+			fmt.Sprintf("%d", entry.Count),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeRecencyCSV(report Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{
+		"label",
+		"min_days",
+		"max_days",
+		"count",
+	}); err != nil {
+		return err
+	}
+
+	for _, entry := range report.RecencySummary {
+		record := []string{
+			entry.Label,
+			formatOptionalInt(entry.MinDays),
+			formatOptionalInt(entry.MaxDays),
+			fmt.Sprintf("%d", entry.Count),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func parseReplayStep(value string) (ReplayStep, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "daily":
+		return ReplayStep{Days: 1}, nil
+	case "weekly":
+		return ReplayStep{Days: 7}, nil
+	case "monthly":
+		return ReplayStep{Months: 1}, nil
+	}
+	if len(value) < 2 {
+		return ReplayStep{}, fmt.Errorf("invalid --replay-step: %q", value)
+	}
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count <= 0 {
+		return ReplayStep{}, fmt.Errorf("invalid --replay-step: %q", value)
+	}
+	switch value[len(value)-1] {
+	case 'd':
+		return ReplayStep{Days: count}, nil
+	case 'w':
+		return ReplayStep{Days: count * 7}, nil
+	case 'm':
+		return ReplayStep{Months: count}, nil
+	}
+	return ReplayStep{}, fmt.Errorf("invalid --replay-step: %q", value)
+}
+
+func (step ReplayStep) String() string {
+	if step.Months > 0 {
+		return fmt.Sprintf("%dm", step.Months)
+	}
+	return fmt.Sprintf("%dd", step.Days)
+}
+
+// replayDates lists as-of dates from start through end. Monthly steps are
+// taken from the start date so month-end starts do not drift.
+func replayDates(start time.Time, end time.Time, step ReplayStep) []time.Time {
+	dates := []time.Time{}
+	for i := 0; ; i++ {
+		date := start.AddDate(0, step.Months*i, step.Days*i)
+		if date.After(end) {
+			break
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// buildReplay recomputes the audit at each as-of date in the range. Rows
+// dated after a snapshot are skipped rather than rejected, so each snapshot
+// only sees the history known on that day.
+func buildReplay(paths []string, opts AuditOptions, start time.Time, end time.Time, step ReplayStep) (Replay, error) {
+	if step.Days <= 0 && step.Months <= 0 {
+		return Replay{}, errors.New("replay step must be positive")
+	}
+	if start.After(end) {
+		return Replay{}, fmt.Errorf("replay start %s is after end %s", formatDate(start), formatDate(end))
+	}
+	replay := Replay{
+		From: formatDate(start),
+		To:   formatDate(end),
+		Step: step.String(),
+	}
+	opts.SkipFuture = true
+	for _, date := range replayDates(start, end, step) {
+		opts.AsOf = date
+		report, err := buildReport(paths, opts)
+		if err != nil {
+			return Replay{}, fmt.Errorf("replay %s: %w", formatDate(date), err)
+		}
+		replay.Tiers = report.Tiers
+		replay.Snapshots = append(replay.Snapshots, ReplaySnapshot{
+			Summary:        report.Summary,
+			ProgramSummary: report.ProgramSummary,
+			report:         report,
+		})
+	}
+	return replay, nil
+}
+
+func printReplay(replay Replay) {
+	fmt.Println("Group Scholar Touchpoint Gap Replay")
+	fmt.Println("======================================")
+	fmt.Printf("Range: %s to %s (step %s)\n", replay.From, replay.To, replay.Step)
+	fmt.Printf("Snapshots: %d\n\n", len(replay.Snapshots))
+	for _, snapshot := range replay.Snapshots {
+		summary := snapshot.Summary
+		fmt.Printf("%s | scholars %d | gap avg %.1f max %d | %s\n",
+			summary.AsOf,
+			summary.TotalScholars,
+			summary.AvgGapDays,
+			summary.MaxGapDays,
+			formatTierCounts(summary.TierCounts, false),
+		)
+	}
+}
+
+func writeReplayJSON(replay Replay, path string) error {
+	data, err := json.MarshalIndent(replay, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func writeReplayCSV(replay Replay, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"as_of",
+		"total_scholars",
+		"avg_gap_days",
+		"median_gap_days",
+		"max_gap_days",
+		"avg_missed_cadences",
+		"max_missed_cadences",
+		"failed_attempts",
+		"input_rows",
+		"rejected_rows",
+	}
+	header = append(header, replay.Tiers...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, snapshot := range replay.Snapshots {
+		summary := snapshot.Summary
+		record := []string{
+			summary.AsOf,
+			fmt.Sprintf("%d", summary.TotalScholars),
+			fmt.Sprintf("%.1f", summary.AvgGapDays),
+			fmt.Sprintf("%.1f", summary.MedianGapDays),
+			fmt.Sprintf("%d", summary.MaxGapDays),
+			fmt.Sprintf("%.1f", summary.AvgMissedCadences),
+			fmt.Sprintf("%d", summary.MaxMissedCadences),
+			fmt.Sprintf("%d", summary.FailedAttempts),
+			fmt.Sprintf("%d", summary.InputRows),
+			fmt.Sprintf("%d", summary.RejectedRows),
+		}
+		for _, tier := range replay.Tiers {
+			record = append(record, fmt.Sprintf("%d", tierCount(summary.TierCounts, tier)))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeReplayProgramsCSV(replay Replay, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{
+		"as_of",
+		"program",
+		"scholars",
+		"avg_gap_days",
+		"avg_missed_cadences",
+	}
+	header = append(header, replay.Tiers...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, snapshot := range replay.Snapshots {
+		for _, entry := range snapshot.ProgramSummary {
+			record := []string{
+				snapshot.Summary.AsOf,
+				entry.Program,
+				fmt.Sprintf("%d", entry.Scholars),
+				fmt.Sprintf("%.1f", entry.AvgGapDays),
+				fmt.Sprintf("%.1f", entry.AvgMissedCadences),
+			}
+			for _, tier := range replay.Tiers {
+				record = append(record, fmt.Sprintf("%d", tierCount(entry.TierCounts, tier)))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func loadRoster(path string, loc *time.Location) (map[string]RosterEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read roster header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	idIdx, ok := findColumn(colMap, scholarIDAliases)
+	if !ok {
+		return nil, errors.New("roster missing scholar_id column")
+	}
+	enrolledIdx, ok := findColumn(colMap, enrollmentAliases)
+	if !ok {
+		return nil, errors.New("roster missing enrollment_date column")
+	}
+	programIdx, _ := findColumn(colMap, programAliases)
+	ownerIdx, _ := findColumn(colMap, ownerAliases)
+
+	roster := map[string]RosterEntry{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read roster: %w", err)
+		}
+		line++
+		scholarID := getValue(record, idIdx)
+		if scholarID == "" {
+			continue
+		}
+		enrolled, err := parseDateIn(getValue(record, enrolledIdx), loc)
+		if err != nil {
+			return nil, fmt.Errorf("roster line %d: invalid enrollment_date for %s: %w", line, scholarID, err)
+		}
+		roster[scholarID] = RosterEntry{
+			ScholarID:      scholarID,
+			Program:        getValue(record, programIdx),
+			Owner:          getValue(record, ownerIdx),
+			EnrollmentDate: dateOnly(enrolled),
+		}
+	}
+	return roster, nil
+}
+
+// loadAcks reads acknowledgements keyed by scholar. A scholar listed more
+// than once keeps the latest snooze date.
+func loadAcks(path string, loc *time.Location) (map[string]Ack, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read acks header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	idIdx, ok := findColumn(colMap, scholarIDAliases)
+	if !ok {
+		return nil, errors.New("acks missing scholar_id column")
+	}
+	untilIdx, ok := findColumn(colMap, snoozeAliases)
+	if !ok {
+		return nil, errors.New("acks missing snooze_until column")
+	}
+	reasonIdx, _ := findColumn(colMap, reasonAliases)
+	ackedByIdx, _ := findColumn(colMap, ackedByAliases)
+
+	acks := map[string]Ack{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read acks: %w", err)
+		}
+		line++
+		scholarID := getValue(record, idIdx)
+		if scholarID == "" {
+			continue
+		}
+		until, err := parseDateIn(getValue(record, untilIdx), loc)
+		if err != nil {
+			return nil, fmt.Errorf("acks line %d: invalid snooze_until for %s: %w", line, scholarID, err)
+		}
+		ack := Ack{
+			ScholarID:   scholarID,
+			SnoozeUntil: dateOnly(until),
+			Reason:      getValue(record, reasonIdx),
+			AckedBy:     getValue(record, ackedByIdx),
+		}
+		if existing, ok := acks[scholarID]; ok && existing.SnoozeUntil.After(ack.SnoozeUntil) {
+			continue
+		}
+		acks[scholarID] = ack
+	}
+	return acks, nil
+}
+
+// loadExclusions reads exclusion windows keyed by scholar. A scholar may
+// have several, e.g. a past leave and a later withdrawal.
+func loadExclusions(path string, loc *time.Location) (map[string][]Exclusion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read exclusions header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	idIdx, ok := findColumn(colMap, scholarIDAliases)
+	if !ok {
+		return nil, errors.New("exclusions missing scholar_id column")
+	}
+	startIdx, _ := findColumn(colMap, startAliases)
+	endIdx, _ := findColumn(colMap, endAliases)
+	reasonIdx, _ := findColumn(colMap, reasonAliases)
+
+	exclusions := map[string][]Exclusion{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read exclusions: %w", err)
+		}
+		line++
+		scholarID := getValue(record, idIdx)
+		if scholarID == "" {
+			continue
+		}
+		exclusion := Exclusion{ScholarID: scholarID, Reason: getValue(record, reasonIdx)}
+		if value := getValue(record, startIdx); value != "" {
+			start, err := parseDateIn(value, loc)
+			if err != nil {
+				return nil, fmt.Errorf("exclusions line %d: invalid start_date for %s: %w", line, scholarID, err)
+			}
+			exclusion.Start = dateOnly(start)
+		}
+		if value := getValue(record, endIdx); value != "" {
+			end, err := parseDateIn(value, loc)
+			if err != nil {
+				return nil, fmt.Errorf("exclusions line %d: invalid end_date for %s: %w", line, scholarID, err)
+			}
+			exclusion.End = dateOnly(end)
+		}
+		if !exclusion.Start.IsZero() && !exclusion.End.IsZero() && exclusion.End.Before(exclusion.Start) {
+			return nil, fmt.Errorf("exclusions line %d: end_date before start_date for %s", line, scholarID)
+		}
+		exclusions[scholarID] = append(exclusions[scholarID], exclusion)
+	}
+	return exclusions, nil
+}
+
+// loadCalendar reads blackout periods. Both dates are required so the
+// clock always restarts.
+func loadCalendar(path string, loc *time.Location) (BlackoutCalendar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read calendar header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	startIdx, ok := findColumn(colMap, startAliases)
+	if !ok {
+		return nil, errors.New("calendar missing start_date column")
+	}
+	endIdx, ok := findColumn(colMap, endAliases)
+	if !ok {
+		return nil, errors.New("calendar missing end_date column")
+	}
+	programIdx, _ := findColumn(colMap, programAliases)
+	nameIdx, _ := findColumn(colMap, nameAliases)
+
+	calendar := BlackoutCalendar{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read calendar: %w", err)
+		}
+		line++
+		start, err := parseDateIn(getValue(record, startIdx), loc)
+		if err != nil {
+			return nil, fmt.Errorf("calendar line %d: invalid start_date: %w", line, err)
+		}
+		end, err := parseDateIn(getValue(record, endIdx), loc)
+		if err != nil {
+			return nil, fmt.Errorf("calendar line %d: invalid end_date: %w", line, err)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("calendar line %d: end_date before start_date", line)
+		}
+		calendar = append(calendar, Blackout{
+			Name:    getValue(record, nameIdx),
+			Program: getValue(record, programIdx),
+			Start:   dateOnly(start),
+			End:     dateOnly(end),
+		})
+	}
+	return calendar, nil
+}
+
+// loadHolidays reads the non-working days used in business-day mode,
+// sorted by date.
+func loadHolidays(path string, loc *time.Location) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read holidays header: %w", err)
+	}
+
+	colMap := normalizeHeaders(headers)
+	dateIdx, ok := findColumn(colMap, holidayAliases)
+	if !ok {
+		return nil, errors.New("holidays missing date column")
+	}
+	nameIdx, _ := findColumn(colMap, nameAliases)
+
+	holidays := []Holiday{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read holidays: %w", err)
+		}
+		line++
+		value := getValue(record, dateIdx)
+		if value == "" {
+			continue
+		}
+		date, err := parseDateIn(value, loc)
+		if err != nil {
+			return nil, fmt.Errorf("holidays line %d: invalid date: %w", line, err)
+		}
+		holidays = append(holidays, Holiday{Date: dateOnly(date), Name: getValue(record, nameIdx)})
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+// windowsFor returns the blackout windows that apply to a program.
+func (calendar BlackoutCalendar) windowsFor(program string) []dateRange {
+	var windows []dateRange
+	for _, blackout := range calendar {
+		if blackout.Program != "" && policyKey(blackout.Program) != policyKey(program) {
+			continue
+		}
+		windows = append(windows, dateRange{Start: blackout.Start, End: blackout.End})
 	}
+	return windows
+}
 
-	for _, entry := range report.Scholars {
-		rank, _ := tierRank(entry.Tier)
-		if rank < threshold {
+// exclusionStatus splits a scholar's exclusions at asOf: the one covering
+// asOf, if any, and the windows of those already over, which pause the
+// cadence clock. Exclusions that have not started yet are ignored.
+func exclusionStatus(exclusions []Exclusion, asOf time.Time) (Exclusion, bool, []dateRange) {
+	asOf = dateOnly(asOf)
+	var pauses []dateRange
+	for _, exclusion := range exclusions {
+		if !exclusion.Start.IsZero() && exclusion.Start.After(asOf) {
 			continue
 		}
-		record := []string{
-			entry.ScholarID,
-			entry.Program,
-			formatDate(entry.LastContact),
-			formatDate(entry.FirstContact),
-			formatDate(entry.NextDueDate),
-			fmt.Sprintf("%d", entry.GapDays),
-			fmt.Sprintf("%d", entry.DaysPastDue),
-			fmt.Sprintf("%d", entry.MissedCadences),
-			fmt.Sprintf("%d", entry.DaysSinceFirst),
-			fmt.Sprintf("%.1f", entry.AvgIntervalDays),
-			fmt.Sprintf("%.1f", entry.ContactsPerMonth),
-			entry.Tier,
-			entry.LastChannel,
-			entry.LastStatus,
-			fmt.Sprintf("%d", entry.ContactCount),
+		if exclusion.End.IsZero() || !exclusion.End.Before(asOf) {
+			return exclusion, true, nil
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+		pauses = append(pauses, dateRange{Start: exclusion.Start, End: exclusion.End})
+	}
+	return Exclusion{}, false, pauses
+}
+
+func (window dateRange) covers(day time.Time) bool {
+	return (window.Start.IsZero() || !day.Before(window.Start)) && (window.End.IsZero() || !day.After(window.End))
+}
+
+func coveredBy(day time.Time, windows []dateRange) bool {
+	for _, window := range windows {
+		if window.covers(day) {
+			return true
 		}
 	}
-	writer.Flush()
-	return writer.Error()
+	return false
 }
 
-func writeProgramCSV(report Report, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
+// cadenceClock decides which days advance a cadence clock: every calendar
+// day by default, only working days in business-day mode, and never a day
+// inside a paused window.
+type cadenceClock struct {
+	businessDays bool
+	holidays     map[int64]bool
+	pauses       []dateRange
+}
+
+// workdays is the clock without any per-scholar pauses.
+func (opts AuditOptions) workdays() cadenceClock {
+	clock := cadenceClock{businessDays: opts.BusinessDays}
+	if opts.BusinessDays && len(opts.Holidays) > 0 {
+		clock.holidays = make(map[int64]bool, len(opts.Holidays))
+		for _, holiday := range opts.Holidays {
+			clock.holidays[civilDay(holiday.Date)] = true
+		}
 	}
-	defer file.Close()
+	return clock
+}
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"program",
-		"scholars",
-		"avg_gap_days",
-		"avg_missed_cadences",
-		"on_track",
-		"due_soon",
-		"overdue",
-		"critical",
-	}); err != nil {
-		return err
+func (clock cadenceClock) withPauses(pauses []dateRange) cadenceClock {
+	clock.pauses = pauses
+	return clock
+}
+
+func (clock cadenceClock) calendarDays() bool {
+	return !clock.businessDays && len(clock.pauses) == 0
+}
+
+func (clock cadenceClock) working(day time.Time) bool {
+	if !clock.businessDays {
+		return true
+	}
+	if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
 	}
+	return !clock.holidays[civilDay(day)]
+}
 
-	for _, entry := range report.ProgramSummary {
-		record := []string{
-			entry.Program,
-			fmt.Sprintf("%d", entry.Scholars),
-			fmt.Sprintf("%.1f", entry.AvgGapDays),
-			fmt.Sprintf("%.1f", entry.AvgMissedCadences),
-			fmt.Sprintf("%d", entry.OnTrackCount),
-			fmt.Sprintf("%d", entry.DueSoonCount),
-			fmt.Sprintf("%d", entry.OverdueCount),
-			fmt.Sprintf("%d", entry.CriticalCount),
+// elapsed counts the days in (from, to] that advance the clock, and the
+// working days skipped because they fell inside a paused window.
+func (clock cadenceClock) elapsed(from time.Time, to time.Time) (int, int) {
+	if clock.calendarDays() {
+		return gapDays(to, from), 0
+	}
+	if from.IsZero() {
+		return 0, 0
+	}
+	counted, paused := 0, 0
+	end := dateOnly(to)
+	for day := dateOnly(from).AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		switch {
+		case !clock.working(day):
+		case coveredBy(day, clock.pauses):
+			paused++
+		default:
+			counted++
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+	}
+	return counted, paused
+}
+
+// advance returns the day on which days clock days have passed since from,
+// which is always a day the clock counts. Every pause must have an end date
+// or the walk would never finish.
+func (clock cadenceClock) advance(from time.Time, days int) time.Time {
+	day := dateOnly(from)
+	if clock.calendarDays() {
+		return day.AddDate(0, 0, days)
+	}
+	for remaining := days; remaining > 0; {
+		day = day.AddDate(0, 0, 1)
+		if clock.working(day) && !coveredBy(day, clock.pauses) {
+			remaining--
 		}
 	}
-	writer.Flush()
-	return writer.Error()
+	return day
 }
 
-func writeChannelCSV(report Report, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
+// daysUntil is the clock days from asOf to due, negative once due has
+// passed.
+func (clock cadenceClock) daysUntil(asOf time.Time, due time.Time) int {
+	if dateOnly(due).Before(dateOnly(asOf)) {
+		return -daysBetween(due, asOf)
 	}
-	defer file.Close()
+	counted, _ := clock.elapsed(asOf, due)
+	return counted
+}
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"channel",
-		"touchpoint_count",
-	}); err != nil {
-		return err
+// snoozeMode is the effective mode, or empty when no acks were loaded.
+func (opts AuditOptions) snoozeMode() string {
+	if opts.Acks == nil {
+		return ""
+	}
+	if opts.SnoozeMode == "" {
+		return snoozeModeSuppress
 	}
+	return opts.SnoozeMode
+}
 
-	channels := make([]string, 0, len(report.ChannelSummary))
-	for channel := range report.ChannelSummary {
-		channels = append(channels, channel)
+// activeAck returns the scholar's ack when its snooze covers asOf.
+func (opts AuditOptions) activeAck(scholarID string, asOf time.Time) (Ack, bool) {
+	ack, ok := opts.Acks[scholarID]
+	if !ok || ack.SnoozeUntil.Before(dateOnly(asOf)) {
+		return Ack{}, false
 	}
-	sort.Strings(channels)
+	return ack, true
+}
 
-	for _, channel := range channels {
-		record := []string{
-			channel,
-			fmt.Sprintf("%d", report.ChannelSummary[channel]),
+// stringList collects repeatable string flags.
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// expandInputs resolves files, directories (every *.csv inside) and glob
+// patterns into a sorted, de-duplicated list of input files.
+func expandInputs(patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	result := []string{}
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			result = append(result, path)
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+	}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			matches, err := filepath.Glob(filepath.Join(pattern, "*.csv"))
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no CSV files found in %s", pattern)
+			}
+			sort.Strings(matches)
+			for _, match := range matches {
+				add(match)
+			}
+			continue
+		}
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input pattern %s: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", pattern)
+			}
+			sort.Strings(matches)
+			for _, match := range matches {
+				add(match)
+			}
+			continue
 		}
+		add(pattern)
 	}
-	writer.Flush()
-	return writer.Error()
+	if len(result) == 0 {
+		return nil, errors.New("--input is required")
+	}
+	return result, nil
 }
 
-func writeStatusCSV(report Report, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
+func (report Report) inputFiles() []string {
+	files := make([]string, 0, len(report.Sources))
+	for _, source := range report.Sources {
+		files = append(files, source.Path)
 	}
-	defer file.Close()
+	return files
+}
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"status",
-		"touchpoint_count",
-	}); err != nil {
-		return err
+func loadColumnMapping(path string) (*ColumnMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
-
-	statuses := make([]string, 0, len(report.StatusSummary))
-	for status := range report.StatusSummary {
-		statuses = append(statuses, status)
+	var mapping ColumnMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("invalid column mapping %s: %w", path, err)
 	}
-	sort.Strings(statuses)
-
-	for _, status := range statuses {
-		record := []string{
-			status,
-			fmt.Sprintf("%d", report.StatusSummary[status]),
+	fields := make([]string, 0, len(mapping.Fields))
+	for field := range mapping.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if _, ok := touchpointFields[field]; !ok {
+			return nil, fmt.Errorf("invalid column mapping %s: unknown field %s", path, field)
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+	}
+	for name, column := range mapping.Passthrough {
+		if strings.TrimSpace(name) == "" || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("invalid column mapping %s: passthrough entries need a name and column", path)
 		}
 	}
-	writer.Flush()
-	return writer.Error()
+	return &mapping, nil
 }
 
-func writeDueCSV(report Report, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
+// resolve finds the column for a logical field. An explicitly mapped column
+// must exist; otherwise extra aliases are tried ahead of the built-in ones.
+func (mapping *ColumnMapping) resolve(headers map[string]int, field string) (int, error) {
+	aliases := touchpointFields[field]
+	if mapping != nil {
+		if configured, ok := mapping.Fields[field]; ok {
+			if configured.Column != "" {
+				idx, found := findColumn(headers, []string{configured.Column})
+				if !found {
+					return -1, fmt.Errorf("mapped column %q not found for %s", configured.Column, field)
+				}
+				return idx, nil
+			}
+			aliases = append(append([]string{}, configured.Aliases...), aliases...)
+		}
 	}
-	defer file.Close()
+	idx, _ := findColumn(headers, aliases)
+	return idx, nil
+}
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"label",
-		"min_days",
-		"max_days",
-		"count",
-	}); err != nil {
-		return err
+func (mapping *ColumnMapping) passthroughNames() []string {
+	if mapping == nil || len(mapping.Passthrough) == 0 {
+		return nil
 	}
-
-	for _, entry := range report.DueSummary {
-		record := []string{
-			entry.Label,
-			formatOptionalInt(entry.MinDays),
-			formatOptionalInt(entry.MaxDays),
-			fmt.Sprintf("%d", entry.Count),
-		}
-		if err := writer.Write(record); err != nil {
-			return err
-		}
+	names := make([]string, 0, len(mapping.Passthrough))
+	for name := range mapping.Passthrough {
+		names = append(names, name)
 	}
-	writer.Flush()
-	return writer.Error()
+	sort.Strings(names)
+	return names
 }
 
-func writeRecencyCSV(report Report, path string) error {
-	file, err := os.Create(path)
+func loadCadencePolicy(path string, fallback CadenceRule) (*CadencePolicy, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	if err := writer.Write([]string{
-		"label",
-		"min_days",
-		"max_days",
-		"count",
-	}); err != nil {
-		return err
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read policy header: %w", err)
 	}
 
-	for _, entry := range report.RecencySummary {
-		record := []string{
-			entry.Label,
-			formatOptionalInt(entry.MinDays),
-			formatOptionalInt(entry.MaxDays),
-			fmt.Sprintf("%d", entry.Count),
+	colMap := normalizeHeaders(headers)
+	programIdx, ok := findColumn(colMap, programAliases)
+	if !ok {
+		return nil, errors.New("policy missing program column")
+	}
+	cadenceIdx, ok := findColumn(colMap, []string{"cadence_days", "cadence"})
+	if !ok {
+		return nil, errors.New("policy missing cadence_days column")
+	}
+	dueWindowIdx, _ := findColumn(colMap, []string{"due_window_days", "due_window"})
+
+	policy := &CadencePolicy{Default: fallback, Programs: map[string]CadenceRule{}}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to read policy: %w", err)
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+		line++
+		program := getValue(record, programIdx)
+		if program == "" {
+			continue
+		}
+		cadence, err := strconv.Atoi(getValue(record, cadenceIdx))
+		if err != nil || cadence <= 0 {
+			return nil, fmt.Errorf("policy line %d: cadence_days must be a positive integer", line)
+		}
+		rule := CadenceRule{CadenceDays: cadence, DueWindowDays: defaultDueWindow(cadence)}
+		if raw := getValue(record, dueWindowIdx); raw != "" {
+			dueWindow, err := strconv.Atoi(raw)
+			if err != nil || dueWindow < 0 {
+				return nil, fmt.Errorf("policy line %d: due_window_days must be a non-negative integer", line)
+			}
+			// An empty cell keeps the cadence/2 default; an explicit 0 means
+			// scholars go overdue as soon as the cadence lapses.
+			rule.DueWindowDays = dueWindow
+		}
+		key := policyKey(program)
+		if key == "default" || key == "*" {
+			policy.Default = rule
+			continue
 		}
+		policy.Programs[key] = rule
 	}
-	writer.Flush()
-	return writer.Error()
+	return policy, nil
+}
+
+func defaultDueWindow(cadenceDays int) int {
+	return int(math.Ceil(float64(cadenceDays) * 0.5))
 }
 
 func parseDate(value string) (time.Time, error) {
+	return parseDateIn(value, nil)
+}
+
+// parseDateIn parses a date or timestamp. With a location, plain values are
+// read as local to it and offset timestamps are converted into it.
+func parseDateIn(value string, loc *time.Location) (time.Time, error) {
 	value = strings.TrimSpace(value)
 	if value == "" {
 		return time.Time{}, errors.New("empty date")
@@ -1466,26 +6140,57 @@ func parseDate(value string) (time.Time, error) {
 		"2006-01-02T15:04:05Z07:00",
 	}
 	for _, layout := range layouts {
-		if parsed, err := time.Parse(layout, value); err == nil {
-			return parsed, nil
+		if loc == nil {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed, nil
+			}
+			continue
+		}
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed.In(loc), nil
 		}
 	}
 	return time.Time{}, fmt.Errorf("unsupported date format: %s", value)
 }
 
-func tierRank(value string) (int, bool) {
-	switch strings.ToLower(strings.TrimSpace(value)) {
-	case "due_soon":
-		return 1, true
-	case "overdue":
-		return 2, true
-	case "critical":
-		return 3, true
-	case "on_track":
-		return 0, true
-	default:
-		return 0, false
+func tierRank(tierNames []string, value string) (int, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for idx, name := range tierNames {
+		if strings.ToLower(name) == value {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+func tierRankOrZero(tierNames []string, value string) int {
+	rank, _ := tierRank(tierNames, value)
+	return rank
+}
+
+func tierLabel(name string) string {
+	label := strings.ReplaceAll(name, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// formatTierCounts renders counts in ladder order, hiding never_contacted
+// when nobody is in it.
+func formatTierCounts(counts []TierCount, capitalize bool) string {
+	parts := make([]string, 0, len(counts))
+	for _, entry := range counts {
+		if entry.Tier == tierNeverContacted && entry.Count == 0 {
+			continue
+		}
+		if capitalize {
+			parts = append(parts, fmt.Sprintf("%s: %d", tierLabel(entry.Tier), entry.Count))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d", strings.ReplaceAll(entry.Tier, "_", " "), entry.Count))
+		}
 	}
+	return strings.Join(parts, " | ")
 }
 
 func formatDate(value time.Time) string {
@@ -1530,6 +6235,13 @@ func getValue(record []string, idx int) string {
 	return strings.TrimSpace(record[idx])
 }
 
+func locationName(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+	return loc.String()
+}
+
 func dateOnly(value time.Time) time.Time {
 	if value.IsZero() {
 		return value
@@ -1537,7 +6249,7 @@ func dateOnly(value time.Time) time.Time {
 	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
 }
 
-func buildDueSummary(entries []ScholarSummary, asOf time.Time) []DueBucketSummary {
+func buildDueSummary(entries []ScholarSummary, asOf time.Time, clock cadenceClock) []DueBucketSummary {
 	defs := dueBucketDefinitions()
 	result := make([]DueBucketSummary, len(defs))
 	for idx, def := range defs {
@@ -1552,7 +6264,7 @@ func buildDueSummary(entries []ScholarSummary, asOf time.Time) []DueBucketSummar
 		index[def.Label] = idx
 	}
 	for _, entry := range entries {
-		label := bucketDueLabel(entry.NextDueDate, asOf)
+		label := bucketDueLabel(entry.NextDueDate, asOf, clock)
 		if pos, ok := index[label]; ok {
 			result[pos].Count++
 		}
@@ -1619,13 +6331,11 @@ func recencyBucketDefinitions() []recencyBucketDefinition {
 	}
 }
 
-func bucketDueLabel(nextDue time.Time, asOf time.Time) string {
+func bucketDueLabel(nextDue time.Time, asOf time.Time, clock cadenceClock) string {
 	if nextDue.IsZero() {
 		return "unknown"
 	}
-	asOfDate := dateOnly(asOf)
-	dueDate := dateOnly(nextDue)
-	daysUntil := int(dueDate.Sub(asOfDate).Hours() / 24)
+	daysUntil := clock.daysUntil(asOf, nextDue)
 	switch {
 	case daysUntil < 0:
 		return "overdue"
@@ -1691,6 +6401,19 @@ func formatRecencySummary(entries []RecencyBucket) string {
 	return strings.Join(parts, " | ")
 }
 
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s %d", key, counts[key]))
+	}
+	return strings.Join(parts, " | ")
+}
+
 func formatOptionalInt(value *int) string {
 	if value == nil {
 		return ""
@@ -1702,6 +6425,10 @@ func intPtr(value int) *int {
 	return &value
 }
 
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
 func exitWithError(err error) {
 	fmt.Fprintln(os.Stderr, "Error:", err)
 	os.Exit(1)
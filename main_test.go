@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func TestBuildReportDedupeDay(t *testing.T) {
@@ -25,7 +32,7 @@ func TestBuildReportDedupeDay(t *testing.T) {
 
 	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
 
-	report, err := buildReport(file.Name(), asOf, 30, 15, 5, true)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, DedupeDay: true})
 	if err != nil {
 		t.Fatalf("build report dedupe: %v", err)
 	}
@@ -39,7 +46,7 @@ func TestBuildReportDedupeDay(t *testing.T) {
 		t.Fatalf("expected avg interval 9.0, got %.1f", report.Scholars[0].AvgIntervalDays)
 	}
 
-	reportRaw, err := buildReport(file.Name(), asOf, 30, 15, 5, false)
+	reportRaw, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5})
 	if err != nil {
 		t.Fatalf("build report raw: %v", err)
 	}
@@ -76,7 +83,7 @@ func TestBuildReportDueSummary(t *testing.T) {
 
 	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
 
-	report, err := buildReport(file.Name(), asOf, 90, 45, 5, false)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 90, DueWindowDays: 45, TopN: 5})
 	if err != nil {
 		t.Fatalf("build report: %v", err)
 	}
@@ -101,10 +108,1774 @@ func TestBuildReportDueSummary(t *testing.T) {
 	}
 }
 
-func floatEqual(a float64, b float64) bool {
-	diff := a - b
-	if diff < 0 {
-		diff = -diff
+func TestBuildReportRosterNeverContacted(t *testing.T) {
+	csvData := "scholar_id,contact_date,channel,program,status\n" +
+		"S-1,2026-01-20,Email,Alpha,Reached\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	rosterFile, err := os.CreateTemp(t.TempDir(), "roster-*.csv")
+	if err != nil {
+		t.Fatalf("temp roster: %v", err)
+	}
+	if _, err := rosterFile.WriteString("scholar_id,program,enrollment_date,advisor\n" +
+		"S-1,Alpha,2025-09-01,Rivera\n" +
+		"S-2,Beta,2025-11-03,Chen\n" +
+		"S-3,Beta,2026-03-01,Chen\n"); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	if err := rosterFile.Close(); err != nil {
+		t.Fatalf("close roster: %v", err)
+	}
+
+	roster, err := loadRoster(rosterFile.Name(), nil)
+	if err != nil {
+		t.Fatalf("load roster: %v", err)
+	}
+
+	badRoster := t.TempDir() + "/bad-roster.csv"
+	if err := os.WriteFile(badRoster, []byte("scholar_id,enrollment_date\nS-1,2025-09-01\nS-2,\n"), 0o644); err != nil {
+		t.Fatalf("write bad roster: %v", err)
+	}
+	if _, err := loadRoster(badRoster, nil); err == nil || !strings.Contains(err.Error(), "roster line 3: invalid enrollment_date for S-2") {
+		t.Fatalf("expected a blank enrollment_date to be rejected, got %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, Roster: roster})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Scholars) != 2 {
+		t.Fatalf("expected 2 scholars (future enrollment skipped), got %d", len(report.Scholars))
+	}
+	if got := tierCount(report.Summary.TierCounts, tierNeverContacted); got != 1 {
+		t.Fatalf("expected 1 never-contacted scholar, got %d", got)
+	}
+
+	var never ScholarSummary
+	for _, entry := range report.Scholars {
+		if entry.ScholarID == "S-2" {
+			never = entry
+		}
+	}
+	if never.Tier != "never_contacted" {
+		t.Fatalf("expected never_contacted tier, got %q", never.Tier)
+	}
+	if never.GapDays != 90 {
+		t.Fatalf("expected gap of 90 days from enrollment, got %d", never.GapDays)
+	}
+	if never.Owner != "Chen" || never.Program != "Beta" {
+		t.Fatalf("expected roster owner/program, got %q/%q", never.Owner, never.Program)
+	}
+}
+
+func TestBuildReportProgramPolicy(t *testing.T) {
+	csvData := "scholar_id,contact_date,channel,program,status\n" +
+		"S-1,2026-01-01,Email,Alpha,Reached\n" +
+		"S-2,2026-01-01,Email,Beta,Reached\n" +
+		"S-3,2026-01-01,Email,,Reached\n" +
+		"S-4,2026-01-01,Email,Gamma,Reached\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	policyFile, err := os.CreateTemp(t.TempDir(), "policy-*.csv")
+	if err != nil {
+		t.Fatalf("temp policy: %v", err)
+	}
+	if _, err := policyFile.WriteString("program,cadence_days,due_window_days\n" +
+		"default,60,\n" +
+		"alpha,14,7\n" +
+		"gamma,30,0\n"); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	if err := policyFile.Close(); err != nil {
+		t.Fatalf("close policy: %v", err)
+	}
+
+	policy, err := loadCadencePolicy(policyFile.Name(), CadenceRule{CadenceDays: 30, DueWindowDays: 15})
+	if err != nil {
+		t.Fatalf("load policy: %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, Policy: policy})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	expect := map[string]struct {
+		cadence   int
+		dueWindow int
+		tier      string
+	}{
+		"S-1": {14, 7, "critical"},
+		"S-2": {60, 30, "on_track"},
+		"S-3": {60, 30, "on_track"},
+		"S-4": {30, 0, "overdue"},
+	}
+	for _, entry := range report.Scholars {
+		want := expect[entry.ScholarID]
+		if entry.CadenceDays != want.cadence || entry.DueWindowDays != want.dueWindow {
+			t.Fatalf("%s expected cadence %d+%d, got %d+%d", entry.ScholarID, want.cadence, want.dueWindow, entry.CadenceDays, entry.DueWindowDays)
+		}
+		if entry.Tier != want.tier {
+			t.Fatalf("%s expected tier %s, got %s", entry.ScholarID, want.tier, entry.Tier)
+		}
+	}
+}
+
+func TestBuildReportSuccessStatuses(t *testing.T) {
+	csvData := "scholar_id,contact_date,channel,program,status\n" +
+		"S-1,2025-12-01,Call,Alpha,Reached\n" +
+		"S-1,2025-12-10,Call,Alpha,No Answer\n" +
+		"S-1,2025-12-20,Call,Alpha,Reached\n" +
+		"S-1,2026-01-05,Call,Alpha,No Answer\n" +
+		"S-1,2026-01-15,SMS,Alpha,Voicemail\n" +
+		"S-1,2026-01-25,Call,Alpha,No Answer\n" +
+		"S-2,2026-01-20,Call,Alpha,No Answer\n" +
+		"S-3,2025-12-01,Call,Alpha,No Answer\n" +
+		"S-3,2025-12-08,Call,Alpha,No Answer\n" +
+		"S-3,2025-12-15,Call,Alpha,No Answer\n" +
+		"S-3,2026-01-10,Call,Alpha,Reached\n" +
+		"S-3,2026-01-20,SMS,Alpha,Voicemail\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{
+		AsOf:            asOf,
+		CadenceDays:     30,
+		DueWindowDays:   15,
+		TopN:            5,
+		SuccessStatuses: parseStatusList("reached, completed"),
+	})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	byID := map[string]ScholarSummary{}
+	for _, entry := range report.Scholars {
+		byID[entry.ScholarID] = entry
+	}
+
+	first := byID["S-1"]
+	if first.GapDays != 43 {
+		t.Fatalf("expected gap from last success (43 days), got %d", first.GapDays)
+	}
+	if first.FailedAttempts != 4 || first.SinceSuccess != 3 || first.FailedStreak != 3 {
+		t.Fatalf("unexpected attempt stats: failed %d, since success %d, streak %d", first.FailedAttempts, first.SinceSuccess, first.FailedStreak)
+	}
+	if first.Tier != "due_soon" {
+		t.Fatalf("expected due_soon, got %s", first.Tier)
+	}
+
+	second := byID["S-2"]
+	if !second.LastSuccess.IsZero() || second.GapDays != 12 {
+		t.Fatalf("expected attempt-only scholar to clock from first attempt, got gap %d", second.GapDays)
+	}
+	third := byID["S-3"]
+	if third.FailedAttempts != 4 || third.SinceSuccess != 1 || third.FailedStreak != 1 {
+		t.Fatalf("expected the current streak, not the longest: failed %d, since success %d, streak %d", third.FailedAttempts, third.SinceSuccess, third.FailedStreak)
+	}
+	if report.Summary.FailedAttempts != 9 {
+		t.Fatalf("expected 9 failed attempts overall, got %d", report.Summary.FailedAttempts)
+	}
+}
+
+func TestBuildReportOwnerSummary(t *testing.T) {
+	csvData := "scholar_id,contact_date,program,case_manager\n" +
+		"S-1,2025-12-01,Alpha,Rivera\n" +
+		"S-1,2026-01-20,Alpha,Chen\n" +
+		"S-2,2025-10-01,Alpha,Chen\n" +
+		"S-3,2026-01-25,Beta,\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.OwnerSummary) != 2 {
+		t.Fatalf("expected 2 owner rows, got %d", len(report.OwnerSummary))
+	}
+
+	chen := report.OwnerSummary[0]
+	if chen.Owner != "Chen" || chen.Scholars != 2 {
+		t.Fatalf("expected Chen with 2 scholars first, got %s with %d", chen.Owner, chen.Scholars)
+	}
+	if tierCount(chen.TierCounts, "critical") != 1 || tierCount(chen.TierCounts, "on_track") != 1 {
+		t.Fatalf("unexpected Chen tier counts: %+v", chen.TierCounts)
+	}
+	if report.OwnerSummary[1].Owner != "Unassigned" {
+		t.Fatalf("expected Unassigned caseload, got %s", report.OwnerSummary[1].Owner)
+	}
+}
+
+func TestBuildReportColumnMapping(t *testing.T) {
+	csvData := "Learner Number,Activity Date,Cohort Year,Region\n" +
+		"S-1,2026-01-10,2025,North\n" +
+		"S-1,2026-01-20,2025,\n" +
+		"S-2,2025-12-01,2024,South\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	mappingFile, err := os.CreateTemp(t.TempDir(), "columns-*.json")
+	if err != nil {
+		t.Fatalf("temp mapping: %v", err)
+	}
+	if _, err := mappingFile.WriteString(`{
+		"fields": {
+			"scholar_id": {"aliases": ["learner_number"]},
+			"contact_date": {"column": "Activity Date"}
+		},
+		"passthrough": {"cohort_year": "Cohort Year", "region": "Region"}
+	}`); err != nil {
+		t.Fatalf("write mapping: %v", err)
+	}
+	if err := mappingFile.Close(); err != nil {
+		t.Fatalf("close mapping: %v", err)
+	}
+
+	mapping, err := loadColumnMapping(mappingFile.Name())
+	if err != nil {
+		t.Fatalf("load mapping: %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, Columns: mapping})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Scholars) != 2 {
+		t.Fatalf("expected 2 scholars, got %d", len(report.Scholars))
+	}
+	if len(report.ExtraFields) != 2 || report.ExtraFields[0] != "cohort_year" {
+		t.Fatalf("unexpected extra fields: %v", report.ExtraFields)
+	}
+	for _, entry := range report.Scholars {
+		if entry.ScholarID == "S-1" && (entry.Extra["region"] != "North" || entry.Extra["cohort_year"] != "2025") {
+			t.Fatalf("expected latest non-empty passthrough values, got %v", entry.Extra)
+		}
+	}
+
+	mapping.Fields["program"] = ColumnField{Column: "Program Name"}
+	mapping.Fields["owner"] = ColumnField{Column: "Advisor Name"}
+	for attempt := 0; attempt < 10; attempt++ {
+		_, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, Columns: mapping})
+		if err == nil || !strings.Contains(err.Error(), "Program Name") {
+			t.Fatalf("expected the program mapping to fail first, got %v", err)
+		}
+	}
+}
+
+func TestBuildReportRejects(t *testing.T) {
+	csvData := "scholar_id,contact_date,channel\n" +
+		"S-1,2026-01-10,Email\n" +
+		",2026-01-11,Email\n" +
+		"S-2,not-a-date,Call\n" +
+		"S-3,2026-03-01,SMS\n" +
+		"S-1,2026-01-10,\"Call, then SMS\"\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, DedupeDay: true})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	expect := map[string]int{
+		"missing_id":         1,
+		"unparseable_date":   1,
+		"future_date":        1,
+		"duplicate_same_day": 0,
+	}
+	for reason, count := range expect {
+		if report.Summary.RejectReasons[reason] != count {
+			t.Fatalf("reason %s expected %d, got %d", reason, count, report.Summary.RejectReasons[reason])
+		}
+	}
+	if report.Summary.InputRows != 5 || report.Summary.RejectedRows != 3 || report.Summary.DuplicateRows != 1 {
+		t.Fatalf("expected 3 of 5 rows rejected plus 1 duplicate, got %d of %d plus %d",
+			report.Summary.RejectedRows, report.Summary.InputRows, report.Summary.DuplicateRows)
+	}
+	if !floatEqual(report.Summary.RejectRate, 0.6) {
+		t.Fatalf("expected reject rate 0.6 excluding merged duplicates, got %.2f", report.Summary.RejectRate)
+	}
+	if report.Rejects[0].Line != 3 || report.Rejects[0].Reason != "missing_id" {
+		t.Fatalf("expected first reject on line 3 for missing_id, got line %d %s", report.Rejects[0].Line, report.Rejects[0].Reason)
+	}
+
+	rejectsPath := t.TempDir() + "/rejects.csv"
+	if err := writeRejectsCSV(report, rejectsPath); err != nil {
+		t.Fatalf("write rejects: %v", err)
+	}
+	data, err := os.ReadFile(rejectsPath)
+	if err != nil {
+		t.Fatalf("read rejects: %v", err)
+	}
+	if !strings.Contains(string(data), `6,duplicate_same_day,S-1,2026-01-10,"S-1,2026-01-10,""Call, then SMS"""`) {
+		t.Fatalf("unexpected rejects CSV:\n%s", data)
+	}
+}
+
+func TestBuildReportMultipleInputs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"launchpad.csv": "scholar_id,contact_date,program\n" +
+			"S-1,2026-01-05,Launchpad\n" +
+			"S-2,bad-date,Launchpad\n",
+		"pioneer.csv": "student_id,touchpoint_date,cohort\n" +
+			"S-1,2026-01-20,Pioneer\n" +
+			"S-3,2026-03-01,Pioneer\n",
+		"notes.txt": "ignored",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(dir+"/"+name, []byte(data), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	paths, err := expandInputs([]string{dir, dir + "/launch*.csv"})
+	if err != nil {
+		t.Fatalf("expand inputs: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 de-duplicated CSV inputs, got %v", paths)
+	}
+
+	asOf := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := buildReport(paths, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Scholars) != 1 || report.Scholars[0].ContactCount != 2 {
+		t.Fatalf("expected S-1 merged across files, got %+v", report.Scholars)
+	}
+	if report.Scholars[0].LastSource != paths[1] {
+		t.Fatalf("expected last source %s, got %s", paths[1], report.Scholars[0].LastSource)
+	}
+	if len(report.Sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(report.Sources))
+	}
+	if report.Sources[0].InvalidRows != 1 || report.Sources[1].FutureRows != 1 {
+		t.Fatalf("unexpected per-source counts: %+v", report.Sources)
+	}
+	if report.Summary.InputRows != 4 {
+		t.Fatalf("expected 4 input rows, got %d", report.Summary.InputRows)
+	}
+}
+
+func TestBuildReportTimezone(t *testing.T) {
+	csvData := "scholar_id,contact_date\n" +
+		"S-1,2026-02-01T03:30:00Z\n" +
+		"S-2,2026-02-10 09:00:00\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	asOf, err := parseDateIn("2026-03-10", loc)
+	if err != nil {
+		t.Fatalf("parse as-of: %v", err)
+	}
+
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, Location: loc})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	byID := map[string]ScholarSummary{}
+	for _, entry := range report.Scholars {
+		byID[entry.ScholarID] = entry
+	}
+	if got := formatDate(byID["S-1"].LastContact); got != "2026-01-31" {
+		t.Fatalf("expected UTC timestamp to land on 2026-01-31 locally, got %s", got)
+	}
+	if byID["S-1"].GapDays != 38 {
+		t.Fatalf("expected 38-day gap across DST change, got %d", byID["S-1"].GapDays)
+	}
+	if got := formatDate(byID["S-2"].NextDueDate); got != "2026-03-12" {
+		t.Fatalf("expected next due 2026-03-12, got %s", got)
+	}
+	if report.Summary.Timezone != "America/Los_Angeles" {
+		t.Fatalf("expected timezone recorded, got %q", report.Summary.Timezone)
+	}
+
+	utc, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report utc: %v", err)
+	}
+	for _, entry := range utc.Scholars {
+		if entry.ScholarID == "S-1" && entry.GapDays != 37 {
+			t.Fatalf("expected 37-day gap without timezone, got %d", entry.GapDays)
+		}
+	}
+}
+
+func TestTierLadderConfigured(t *testing.T) {
+	ladderFile, err := os.CreateTemp(t.TempDir(), "tiers-*.json")
+	if err != nil {
+		t.Fatalf("temp tiers: %v", err)
+	}
+	if _, err := ladderFile.WriteString(`{"tiers": [
+		{"name": "on_track", "max_cadence_multiple": 1},
+		{"name": "due_soon", "max_cadence_multiple": 1, "plus_due_window": true},
+		{"name": "overdue", "max_cadence_multiple": 2},
+		{"name": "escalate", "max_days": 90},
+		{"name": "critical"}
+	]}`); err != nil {
+		t.Fatalf("write tiers: %v", err)
+	}
+	if err := ladderFile.Close(); err != nil {
+		t.Fatalf("close tiers: %v", err)
+	}
+
+	ladder, err := loadTierLadder(ladderFile.Name())
+	if err != nil {
+		t.Fatalf("load tiers: %v", err)
+	}
+
+	cases := map[int]string{
+		30:  "on_track",
+		45:  "due_soon",
+		60:  "overdue",
+		61:  "escalate",
+		90:  "escalate",
+		91:  "critical",
+		400: "critical",
+	}
+	for gap, want := range cases {
+		if got := ladder.classify(gap, 30, 15); got != want {
+			t.Fatalf("gap %d expected %s, got %s", gap, want, got)
+		}
+	}
+
+	rank, ok := ladder.rank("escalate")
+	if !ok || rank != 3 {
+		t.Fatalf("expected escalate rank 3, got %d (%v)", rank, ok)
+	}
+	if rank, _ := ladder.rank(tierNeverContacted); rank != 5 {
+		t.Fatalf("expected never_contacted ranked last, got %d", rank)
+	}
+
+	invalid := &TierLadder{Tiers: []TierDefinition{{Name: "on_track"}, {Name: "critical"}}}
+	if err := invalid.validate(); err == nil {
+		t.Fatalf("expected unbounded non-final tier to be rejected")
+	}
+	shrinking := &TierLadder{Tiers: []TierDefinition{
+		{Name: "on_track", MaxCadenceMultiple: floatPtr(2)},
+		{Name: "overdue", MaxCadenceMultiple: floatPtr(1), PlusDueWindow: true},
+		{Name: "critical"},
+	}}
+	if err := shrinking.validate(); err == nil {
+		t.Fatalf("expected a decreasing cadence bound to be rejected")
+	}
+	repeated := &TierLadder{Tiers: []TierDefinition{
+		{Name: "on_track", MaxDays: intPtr(30)},
+		{Name: "overdue", MaxDays: intPtr(30)},
+		{Name: "critical"},
+	}}
+	if err := repeated.validate(); err == nil {
+		t.Fatalf("expected a repeated max_days bound to be rejected")
+	}
+
+	counts := []TierCount{{Tier: "on_track", Count: 2}, {Tier: tierNeverContacted, Count: 1}}
+	custom := legacyTierColumns(counts, ladder.names())
+	if custom[0] != (sql.NullInt64{}) || custom[4] != 1 {
+		t.Fatalf("expected NULL legacy counts for a custom ladder, got %v", custom)
+	}
+	scheduled := legacyTierColumns(counts, withScheduledTier(defaultTierLadder().names()))
+	if scheduled[0] != 2 || scheduled[4] != 1 {
+		t.Fatalf("expected legacy counts for the default ladder, got %v", scheduled)
+	}
+}
+
+func floatEqual(a float64, b float64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < 0.01
+}
+
+func TestBuildReportScheduleFuture(t *testing.T) {
+	csvData := "scholar_id,contact_date,program\n" +
+		"S-1,2026-01-20,Launchpad\n" +
+		"S-1,2026-03-14,Launchpad\n" +
+		"S-2,2026-01-20,Launchpad\n" +
+		"S-2,2026-05-01,Launchpad\n" +
+		"S-3,2026-03-12,Bridge\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	asOf := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	ignored, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if ignored.Summary.FutureRows != 3 || ignored.Summary.RejectedRows != 3 {
+		t.Fatalf("expected 3 rejected future rows, got future=%d rejected=%d", ignored.Summary.FutureRows, ignored.Summary.RejectedRows)
+	}
+
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, ScheduleFuture: true})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if report.Summary.RejectedRows != 0 {
+		t.Fatalf("expected no rejected rows, got %d", report.Summary.RejectedRows)
+	}
+	if report.Tiers[1] != tierScheduled {
+		t.Fatalf("expected scheduled tier after on_track, got %v", report.Tiers)
+	}
+	byID := map[string]ScholarSummary{}
+	for _, entry := range report.Scholars {
+		byID[entry.ScholarID] = entry
+	}
+	if byID["S-1"].Tier != tierScheduled || formatDate(byID["S-1"].ScheduledDate) != "2026-03-14" {
+		t.Fatalf("expected S-1 scheduled for 2026-03-14, got %s %s", byID["S-1"].Tier, formatDate(byID["S-1"].ScheduledDate))
+	}
+	if byID["S-2"].Tier == tierScheduled {
+		t.Fatalf("expected S-2 booking outside the due window to keep its tier")
+	}
+	if _, ok := byID["S-3"]; ok || len(report.Scholars) != 2 {
+		t.Fatalf("expected a booking alone not to create S-3, got %d scholars", len(report.Scholars))
+	}
+	if tierCount(report.Summary.TierCounts, tierScheduled) != 1 {
+		t.Fatalf("expected 1 scheduled scholar, got %v", report.Summary.TierCounts)
+	}
+
+	roster := map[string]RosterEntry{"S-3": {ScholarID: "S-3", EnrollmentDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)}}
+	rostered, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, ScheduleFuture: true, Roster: roster})
+	if err != nil {
+		t.Fatalf("build rostered report: %v", err)
+	}
+	for _, entry := range rostered.Scholars {
+		if entry.ScholarID == "S-3" && (entry.Tier != tierNeverContacted || formatDate(entry.ScheduledDate) != "2026-03-12") {
+			t.Fatalf("expected rostered S-3 to stay never_contacted with its booking shown, got %s %s", entry.Tier, formatDate(entry.ScheduledDate))
+		}
+	}
+	if len(rostered.Scholars) != 3 {
+		t.Fatalf("expected the roster to bring in S-3, got %d scholars", len(rostered.Scholars))
+	}
+
+	ladder := defaultTierLadder()
+	lastContact := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	nextDue := lastContact.AddDate(0, 0, 30)
+	booked := asOf.AddDate(0, 0, 3)
+	windowEnd := asOf.AddDate(0, 0, 15)
+	if isScheduled("critical", ladder, booked, nextDue, asOf, windowEnd) {
+		t.Fatalf("expected critical scholars to stay critical despite a booking")
+	}
+	early := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if isScheduled("due_soon", ladder, asOf.AddDate(0, 0, 5), asOf.AddDate(0, 0, 2), early, early.AddDate(0, 0, 15)) {
+		t.Fatalf("expected a booking after a future next due date not to count")
+	}
+	if !isScheduled("due_soon", ladder, asOf.AddDate(0, 0, 2), asOf.AddDate(0, 0, 2), early, early.AddDate(0, 0, 15)) {
+		t.Fatalf("expected a booking on the next due date to count")
+	}
+}
+
+func TestBuildReplay(t *testing.T) {
+	csvData := "scholar_id,contact_date,program\n" +
+		"S-1,2026-01-01,Launchpad\n" +
+		"S-1,2026-02-10,Launchpad\n" +
+		"S-2,2026-01-20,Bridge\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	step, err := parseReplayStep("2w")
+	if err != nil {
+		t.Fatalf("parse step: %v", err)
+	}
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	replay, err := buildReplay([]string{file.Name()}, AuditOptions{CadenceDays: 30, DueWindowDays: 15, TopN: 5}, start, end, step)
+	if err != nil {
+		t.Fatalf("build replay: %v", err)
+	}
+	if len(replay.Snapshots) != 4 {
+		t.Fatalf("expected 4 snapshots, got %d", len(replay.Snapshots))
+	}
+	first := replay.Snapshots[0].Summary
+	if first.AsOf != "2026-01-05" || first.TotalScholars != 1 || first.RejectedRows != 0 {
+		t.Fatalf("expected first snapshot to see only S-1 without rejects, got %+v", first)
+	}
+	third := replay.Snapshots[2].Summary
+	if third.AsOf != "2026-02-02" || tierCount(third.TierCounts, "due_soon") != 1 {
+		t.Fatalf("expected S-1 due soon on 2026-02-02, got %v", third.TierCounts)
+	}
+	last := replay.Snapshots[3].Summary
+	if last.TotalScholars != 2 || tierCount(last.TierCounts, "on_track") != 2 {
+		t.Fatalf("expected both scholars on track at the end, got %v", last.TierCounts)
+	}
+
+	out := t.TempDir() + "/replay.csv"
+	if err := writeReplayCSV(replay, out); err != nil {
+		t.Fatalf("write replay csv: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read replay csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 5 || !strings.HasSuffix(lines[0], ",on_track,due_soon,overdue,critical,never_contacted") {
+		t.Fatalf("unexpected replay csv:\n%s", data)
+	}
+
+	if _, err := parseReplayStep("fortnightly"); err == nil {
+		t.Fatalf("expected invalid step error")
+	}
+
+	snapshot, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: start, CadenceDays: 30, DueWindowDays: 15, TopN: 5, SkipFuture: true, ScheduleFuture: true})
+	if err != nil {
+		t.Fatalf("build snapshot: %v", err)
+	}
+	if snapshot.Summary.TotalScholars != 1 || !snapshot.Scholars[0].ScheduledDate.IsZero() {
+		t.Fatalf("expected later touchpoints dropped rather than booked, got %+v", snapshot.Scholars)
+	}
+}
+
+func TestHistoryTiers(t *testing.T) {
+	runs := []HistoryRun{
+		{RunID: "a", TierCounts: legacyTierCounts(3, 1, 2, 0, 0)},
+		{RunID: "b", TierCounts: []TierCount{
+			{Tier: "on_track", Count: 4},
+			{Tier: "due_soon", Count: 1},
+			{Tier: "overdue", Count: 1},
+			{Tier: "escalate", Count: 2},
+			{Tier: "critical", Count: 0},
+		}},
+	}
+	got := strings.Join(historyTiers(runs), ",")
+	if got != "on_track,due_soon,overdue,critical,never_contacted,escalate" {
+		t.Fatalf("unexpected history tiers: %s", got)
+	}
+	if tierCount(runs[0].TierCounts, "overdue") != 2 || tierCount(runs[1].TierCounts, "never_contacted") != 0 {
+		t.Fatalf("unexpected tier counts: %v", runs)
+	}
+}
+
+func TestCompareReports(t *testing.T) {
+	tiers := defaultTierLadder().names()
+	base := Report{
+		Summary: ReportSummary{AsOf: "2026-02-01"},
+		Tiers:   tiers,
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Program: "Launchpad", Tier: "on_track", GapDays: 10},
+			{ScholarID: "S-2", Program: "Launchpad", Tier: "critical", GapDays: 90},
+			{ScholarID: "S-3", Program: "Bridge", Tier: "due_soon", GapDays: 35},
+			{ScholarID: "S-4", Program: "Bridge", Tier: "overdue", GapDays: 50},
+		},
+	}
+	current := Report{
+		Summary: ReportSummary{AsOf: "2026-02-08"},
+		Tiers:   tiers,
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Program: "Launchpad", Tier: "critical", GapDays: 17},
+			{ScholarID: "S-2", Program: "Launchpad", Tier: "on_track", GapDays: 2},
+			{ScholarID: "S-3", Program: "Bridge", Tier: "due_soon", GapDays: 42},
+			{ScholarID: "S-5", Tier: tierNeverContacted, GapDays: 12},
+		},
+	}
+
+	comparison := compareReports(base, current)
+	counts := comparison.ChangeCounts
+	if counts[changeWorsened] != 1 || counts[changeImproved] != 1 || counts[changeNew] != 1 || counts[changeRemoved] != 1 {
+		t.Fatalf("unexpected change counts: %v", counts)
+	}
+	if first := comparison.Changes[0]; first.ScholarID != "S-1" || first.Change != changeWorsened || first.TierRankChange != 3 {
+		t.Fatalf("expected S-1 worsened first, got %+v", first)
+	}
+
+	legacyBase, legacyCurrent := base, current
+	legacyBase.Tiers, legacyCurrent.Tiers = nil, nil
+	legacy := compareReports(legacyBase, legacyCurrent)
+	if len(legacy.UnknownTiers) != 0 || legacy.ChangeCounts[changeWorsened] != 1 || len(legacy.Tiers) != len(tiers) {
+		t.Fatalf("expected reports without tiers to use the default ladder, got %+v", legacy)
+	}
+
+	programs := map[string]ProgramDelta{}
+	for _, entry := range comparison.Programs {
+		programs[entry.Program] = entry
+	}
+	bridge := programs["Bridge"]
+	if bridge.BaseScholars != 2 || bridge.CurrentScholars != 1 || bridge.Removed != 1 {
+		t.Fatalf("unexpected Bridge delta: %+v", bridge)
+	}
+	if programs["Unassigned"].New != 1 {
+		t.Fatalf("expected new unassigned scholar, got %+v", programs["Unassigned"])
+	}
+	for _, delta := range comparison.TierDeltas {
+		if delta.Tier == "overdue" && delta.Delta != -1 {
+			t.Fatalf("expected overdue delta -1, got %+v", delta)
+		}
+	}
+
+	path := t.TempDir() + "/changes.csv"
+	if err := writeComparisonCSV(comparison, path); err != nil {
+		t.Fatalf("write comparison csv: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read comparison csv: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 5 {
+		t.Fatalf("expected header plus 4 changes, got:\n%s", data)
+	}
+
+	// Base ran with --schedule-future, current did not; unchanged tiers must
+	// stay unchanged and the scheduled tier must rank below due_soon.
+	base.Tiers = withScheduledTier(tiers)
+	base.Scholars = []ScholarSummary{
+		{ScholarID: "S-1", Tier: "critical"},
+		{ScholarID: "S-2", Tier: tierScheduled},
+		{ScholarID: "S-3", Tier: "mystery"},
+	}
+	current.Scholars = []ScholarSummary{
+		{ScholarID: "S-1", Tier: "critical"},
+		{ScholarID: "S-2", Tier: "due_soon"},
+		{ScholarID: "S-3", Tier: "overdue"},
+	}
+	mixed := compareReports(base, current)
+	if len(mixed.Tiers) != 6 || mixed.Tiers[1] != tierScheduled || mixed.Tiers[5] != tierNeverContacted {
+		t.Fatalf("expected scheduled merged after on_track, got %v", mixed.Tiers)
+	}
+	if len(mixed.Changes) != 2 {
+		t.Fatalf("expected only S-2 and S-3 to change, got %+v", mixed.Changes)
+	}
+	if first := mixed.Changes[0]; first.ScholarID != "S-2" || first.Change != changeWorsened || first.TierRankChange != 1 {
+		t.Fatalf("expected S-2 to worsen by one tier, got %+v", first)
+	}
+	if second := mixed.Changes[1]; second.ScholarID != "S-3" || second.Change != changeUnranked {
+		t.Fatalf("expected S-3 move from an unknown tier to be unranked, got %+v", second)
+	}
+	if len(mixed.UnknownTiers) != 1 || mixed.UnknownTiers[0] != "mystery" {
+		t.Fatalf("expected mystery reported as unknown, got %v", mixed.UnknownTiers)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations(postgresMigrations)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("expected baseline migration first, got %+v", migrations)
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Fatalf("expected contiguous versions, got %d at position %d", migration.Version, i)
+		}
+		rendered := migration.render("audit_test")
+		if strings.Contains(rendered, "{{schema}}") || strings.Contains(rendered, "%s") {
+			t.Fatalf("migration %s left placeholders unrendered", migration.Name)
+		}
+	}
+	if !strings.Contains(migrations[0].render("audit_test"), "audit_test.audit_runs") {
+		t.Fatalf("expected baseline to create audit_runs in the target schema")
+	}
+
+	sqlite, err := loadMigrations(sqliteMigrations)
+	if err != nil {
+		t.Fatalf("load sqlite migrations: %v", err)
+	}
+	for i, migration := range sqlite {
+		if migration.Version != i+1 {
+			t.Fatalf("expected contiguous sqlite versions, got %d at position %d", migration.Version, i)
+		}
+	}
+
+	latest := latestMigrationVersion(migrations)
+	applied := map[int]MigrationStatus{
+		1:          {Version: 1, Name: migrations[0].Name, AppliedAt: time.Now()},
+		latest + 1: {Version: latest + 1, Name: "9999_future"},
+	}
+	statuses := migrationStatuses(migrations, applied)
+	last := statuses[len(statuses)-1]
+	if last.Version != latest+1 || last.Known {
+		t.Fatalf("expected unknown future migration listed last, got %+v", last)
+	}
+	if statuses[0].AppliedAt.IsZero() {
+		t.Fatalf("expected baseline marked applied")
+	}
+}
+
+func TestReportCopyTablesEncode(t *testing.T) {
+	csvData := "scholar_id,contact_date,program,channel,status\n" +
+		"S-1,2026-01-10,Launchpad,Email,Reached\n" +
+		"S-2,2025-11-01,,Call,No Answer\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	// Column types as declared in the baseline migration, so every value
+	// must encode the way COPY sends it.
+	columnTypes := map[string]uint32{
+		"id": pgtype.UUIDOID, "run_id": pgtype.UUIDOID,
+		"enrollment_date": pgtype.DateOID, "last_contact": pgtype.DateOID, "last_successful_contact": pgtype.DateOID,
+		"first_contact": pgtype.DateOID, "next_due_date": pgtype.DateOID, "scheduled_date": pgtype.DateOID, "tier_since": pgtype.DateOID,
+		"avg_interval_days": pgtype.NumericOID, "contacts_per_month": pgtype.NumericOID,
+		"avg_gap_days": pgtype.NumericOID, "avg_missed_cadences": pgtype.NumericOID,
+	}
+	textColumns := map[string]bool{
+		"scholar_id": true, "program": true, "owner": true, "last_channel": true, "last_status": true,
+		"tier": true, "channel": true, "status": true, "scope": true, "scope_key": true, "label": true,
+	}
+	typeMap := pgtype.NewMap()
+	tables := reportCopyTables(report, uuid.New())
+	if len(tables) != 8 || len(tables[0].rows) != 2 {
+		t.Fatalf("unexpected copy tables: %d tables, %d scholar rows", len(tables), len(tables[0].rows))
+	}
+	for _, table := range tables {
+		for _, row := range table.rows {
+			if len(row) != len(table.columns) {
+				t.Fatalf("%s: %d values for %d columns", table.table, len(row), len(table.columns))
+			}
+			for i, value := range row {
+				oid, ok := columnTypes[table.columns[i]]
+				if !ok {
+					oid = pgtype.Int4OID
+					if textColumns[table.columns[i]] {
+						oid = pgtype.TextOID
+					}
+				}
+				if _, err := typeMap.Encode(oid, pgtype.BinaryFormatCode, value, nil); err != nil {
+					t.Fatalf("%s.%s: encode %T: %v", table.table, table.columns[i], value, err)
+				}
+			}
+		}
+	}
+
+	var progress strings.Builder
+	source := &progressCopySource{rows: [][]any{{1}, {2}, {3}, {4}, {5}}, table: "audit_scholar_gaps", progress: &progress, every: 2}
+	count := 0
+	for source.Next() {
+		if _, err := source.Values(); err != nil {
+			t.Fatalf("values: %v", err)
+		}
+		count++
+	}
+	if count != 5 || strings.Count(progress.String(), "\n") != 2 {
+		t.Fatalf("expected 5 rows with 2 progress lines, got %d rows:\n%s", count, progress.String())
+	}
+}
+
+func TestAuditFingerprint(t *testing.T) {
+	csvData := "scholar_id,contact_date\nS-1,2026-01-10\nS-2,2025-12-01\n"
+	dir := t.TempDir()
+	first := dir + "/first.csv"
+	second := dir + "/second.csv"
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte(csvData), 0644); err != nil {
+			t.Fatalf("write csv: %v", err)
+		}
+	}
+
+	opts := AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5}
+	build := func(path string, opts AuditOptions) Report {
+		report, err := buildReport([]string{path}, opts)
+		if err != nil {
+			t.Fatalf("build report: %v", err)
+		}
+		return report
+	}
+
+	base := build(first, opts)
+	if len(base.Summary.InputFingerprint) != 64 || len(base.Sources[0].SHA256) != 64 {
+		t.Fatalf("expected sha256 fingerprints, got %q / %q", base.Summary.InputFingerprint, base.Sources[0].SHA256)
+	}
+	if got := build(second, opts).Summary.InputFingerprint; got != base.Summary.InputFingerprint {
+		t.Fatalf("expected identical content at another path to share a fingerprint")
+	}
+	for name, changed := range map[string]AuditOptions{
+		"as-of":    {AsOf: opts.AsOf.AddDate(0, 0, 1), CadenceDays: 30, DueWindowDays: 15, TopN: 5},
+		"cadence":  {AsOf: opts.AsOf, CadenceDays: 45, DueWindowDays: 15, TopN: 5},
+		"dedupe":   {AsOf: opts.AsOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, DedupeDay: true},
+		"top":      {AsOf: opts.AsOf, CadenceDays: 30, DueWindowDays: 15, TopN: 1},
+		"min-tier": {AsOf: opts.AsOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5, MinTier: "critical"},
+	} {
+		if got := build(first, changed).Summary.InputFingerprint; got == base.Summary.InputFingerprint {
+			t.Fatalf("expected %s change to alter the fingerprint", name)
+		}
+	}
+
+	if err := os.WriteFile(second, []byte(csvData+"S-3,2026-01-20\n"), 0644); err != nil {
+		t.Fatalf("rewrite csv: %v", err)
+	}
+	if got := build(second, opts).Summary.InputFingerprint; got == base.Summary.InputFingerprint {
+		t.Fatalf("expected changed file content to alter the fingerprint")
+	}
+}
+
+func TestReportRunParameters(t *testing.T) {
+	csvData := "scholar_id,contact_date\nS-1,2026-01-10\nS-1,2026-01-10\nS-2,2025-11-01\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	policy := &CadencePolicy{Default: CadenceRule{CadenceDays: 30, DueWindowDays: 15}, Programs: map[string]CadenceRule{}}
+	report, err := buildReport([]string{file.Name()}, AuditOptions{
+		AsOf:          time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		CadenceDays:   30,
+		DueWindowDays: 15,
+		TopN:          3,
+		DedupeDay:     true,
+		MinTier:       "due_soon",
+		Policy:        policy,
+	})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	summary := report.Summary
+	if !summary.DedupeDay || summary.TopN != 3 || summary.MinTier != "due_soon" || summary.ToolVersion == "" {
+		t.Fatalf("expected run parameters on summary, got %+v", summary)
+	}
+	if report.Parameters.Policy != policy || report.Parameters.Ladder == nil {
+		t.Fatalf("expected policy and ladder recorded, got %+v", report.Parameters)
+	}
+
+	var due copyTable
+	for _, table := range reportCopyTables(report, uuid.New()) {
+		if table.table == "audit_due_summary" {
+			due = table
+		}
+	}
+	if len(due.rows) != len(report.DueSummary) || len(due.rows) == 0 {
+		t.Fatalf("expected %d due summary rows, got %d", len(report.DueSummary), len(due.rows))
+	}
+}
+
+func TestWriteExports(t *testing.T) {
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2026-01-10,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-10-01,Bridge,Call,Reached,Chen\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	dir := t.TempDir()
+	paths := ExportPaths{
+		JSON:     dir + "/report.json",
+		Alerts:   dir + "/alerts.csv",
+		MinTier:  "overdue",
+		Programs: dir + "/programs.csv",
+		Owners:   dir + "/owners.csv",
+		Channels: dir + "/channels.csv",
+		Statuses: dir + "/statuses.csv",
+		Due:      dir + "/due.csv",
+		Recency:  dir + "/recency.csv",
+	}
+	if err := writeExports(report, paths); err != nil {
+		t.Fatalf("write exports: %v", err)
+	}
+	for _, path := range []string{paths.JSON, paths.Alerts, paths.Programs, paths.Owners, paths.Channels, paths.Statuses, paths.Due, paths.Recency} {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() == 0 {
+			t.Fatalf("expected %s to be written: %v", path, err)
+		}
+	}
+	alerts, err := os.ReadFile(paths.Alerts)
+	if err != nil {
+		t.Fatalf("read alerts: %v", err)
+	}
+	if !strings.Contains(string(alerts), "S-2") || strings.Contains(string(alerts), "S-1,") {
+		t.Fatalf("expected only S-2 in overdue alerts, got:\n%s", alerts)
+	}
+
+	if err := writeExports(report, ExportPaths{}); err != nil {
+		t.Fatalf("expected empty export paths to be a no-op: %v", err)
+	}
+}
+
+func TestSQLiteStoreRoundTrip(t *testing.T) {
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2026-01-10,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-10-01,Bridge,Call,Reached,Chen\n" +
+		"S-3,2025-08-15,Bridge,Text,Reached,Chen\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	cfg := DBConfig{URL: "sqlite://" + t.TempDir() + "/audit.db", Tag: "local"}
+	first, err := storeReportInDB(report, cfg)
+	if err != nil {
+		t.Fatalf("store report: %v", err)
+	}
+	if _, err := uuid.Parse(first.RunID); err != nil {
+		t.Fatalf("expected a run id, got %q", first.RunID)
+	}
+
+	cfg.Mode = storeModeSkip
+	skipped, err := storeReportInDB(report, cfg)
+	if err != nil {
+		t.Fatalf("store skip: %v", err)
+	}
+	if !skipped.Skipped || skipped.RunID != first.RunID {
+		t.Fatalf("expected skip to return run %s, got %+v", first.RunID, skipped)
+	}
+
+	cfg.Mode = storeModeReplace
+	replaced, err := storeReportInDB(report, cfg)
+	if err != nil {
+		t.Fatalf("store replace: %v", err)
+	}
+	if replaced.Replaced != 1 || replaced.RunID == first.RunID {
+		t.Fatalf("expected replace to swap out one run, got %+v", replaced)
+	}
+
+	other := cfg
+	other.Tag = "nightly"
+	other.Mode = storeModeSkip
+	tagged, err := storeReportInDB(report, other)
+	if err != nil {
+		t.Fatalf("store other tag: %v", err)
+	}
+	if tagged.Skipped || tagged.RunID == replaced.RunID {
+		t.Fatalf("expected skip to ignore runs under another tag, got %+v", tagged)
+	}
+	other.Mode = storeModeReplace
+	retagged, err := storeReportInDB(report, other)
+	if err != nil {
+		t.Fatalf("replace other tag: %v", err)
+	}
+	if retagged.Replaced != 1 {
+		t.Fatalf("expected replace to remove only the nightly run, got %+v", retagged)
+	}
+
+	ctx := context.Background()
+	store, err := openSQLiteStore(ctx, strings.TrimPrefix(cfg.URL, sqliteURLPrefix))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	if count, err := store.RunCount(ctx); err != nil || count != 2 {
+		t.Fatalf("expected 2 stored runs, got %d (%v)", count, err)
+	}
+	var scholars int
+	var asOf, lastContact string
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_scholar_gaps`).Scan(&scholars); err != nil {
+		t.Fatalf("count scholars: %v", err)
+	}
+	if scholars != 6 {
+		t.Fatalf("expected replace to cascade to 3 scholar rows per run, got %d", scholars)
+	}
+	if err := store.db.QueryRowContext(ctx, `
+		SELECT r.as_of, g.last_contact
+		FROM audit_runs r JOIN audit_scholar_gaps g ON g.run_id = r.id
+		WHERE g.scholar_id = 'S-1' AND r.run_tag = 'local'`).Scan(&asOf, &lastContact); err != nil {
+		t.Fatalf("read scholar row: %v", err)
+	}
+	if asOf != "2026-02-01" || lastContact != "2026-01-10" {
+		t.Fatalf("expected date text columns, got as_of=%s last_contact=%s", asOf, lastContact)
+	}
+}
+
+func TestTierTransitions(t *testing.T) {
+	february := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	report := Report{
+		Summary: ReportSummary{AsOf: "2026-03-15"},
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Tier: "critical"},
+			{ScholarID: "S-2", Tier: "critical"},
+			{ScholarID: "S-3", Tier: "on_track"},
+		},
+	}
+	report.TopGaps = report.Scholars[:1]
+	prior := map[string]tierState{
+		"S-1": {Tier: "overdue", Since: february},
+		"S-2": {Tier: "critical", Since: february},
+	}
+
+	since, transitions := tierTransitions(report, prior, march)
+	if !since["S-1"].Equal(march) || !since["S-2"].Equal(february) || !since["S-3"].Equal(march) {
+		t.Fatalf("unexpected tier since dates: %v", since)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %+v", transitions)
+	}
+	if transitions[0] != (tierTransition{ScholarID: "S-1", FromTier: "overdue", ToTier: "critical"}) {
+		t.Fatalf("unexpected escalation transition: %+v", transitions[0])
+	}
+	if transitions[1] != (tierTransition{ScholarID: "S-3", ToTier: "on_track"}) {
+		t.Fatalf("expected first sighting with no from tier, got %+v", transitions[1])
+	}
+
+	annotated := withTierSince(report, since)
+	if report.Scholars[1].DaysInTier != nil {
+		t.Fatalf("expected the original report to be left untouched")
+	}
+	if days := annotated.Scholars[1].DaysInTier; days == nil || *days != 42 {
+		t.Fatalf("expected S-2 to be 42 days in tier, got %v", days)
+	}
+	if days := annotated.TopGaps[0].DaysInTier; days == nil || *days != 0 {
+		t.Fatalf("expected top gaps to be annotated too, got %v", days)
+	}
+}
+
+func TestSQLitePathEscaping(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit?mode=ro#1%.db")
+	ctx := context.Background()
+	store, err := openSQLiteStore(ctx, path)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	var foreignKeys int
+	if err := store.db.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&foreignKeys); err != nil {
+		t.Fatalf("read pragma: %v", err)
+	}
+	if foreignKeys != 1 {
+		t.Fatalf("expected foreign keys to stay enabled, got %d", foreignKeys)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) == 0 || entries[0].Name() != filepath.Base(path) {
+		t.Fatalf("expected the database at %q, got %v", filepath.Base(path), entries)
+	}
+}
+
+func TestSQLiteTierHistory(t *testing.T) {
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2026-01-10,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-08-15,Bridge,Call,Reached,Chen\n"
+
+	file, err := os.CreateTemp(t.TempDir(), "touchpoints-*.csv")
+	if err != nil {
+		t.Fatalf("temp file: %v", err)
+	}
+	if _, err := file.WriteString(csvData); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close csv: %v", err)
+	}
+
+	cfg := DBConfig{URL: "sqlite://" + t.TempDir() + "/audit.db"}
+	var last StoredRun
+	var lastReport Report
+	for _, asOf := range []time.Time{
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	} {
+		report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: asOf, CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+		if err != nil {
+			t.Fatalf("build report: %v", err)
+		}
+		if last, err = storeReportInDB(report, cfg); err != nil {
+			t.Fatalf("store %s: %v", report.Summary.AsOf, err)
+		}
+		lastReport = withTierSince(report, last.TierSince)
+	}
+
+	for _, entry := range lastReport.Scholars {
+		switch entry.ScholarID {
+		case "S-1":
+			if formatDate(entry.TierSince) != "2026-03-15" || entry.DaysInTier == nil || *entry.DaysInTier != 0 {
+				t.Fatalf("expected S-1 to have just changed tier, got %+v", entry)
+			}
+		case "S-2":
+			if formatDate(entry.TierSince) != "2026-02-01" || entry.DaysInTier == nil || *entry.DaysInTier != 42 {
+				t.Fatalf("expected S-2 to stay critical since February, got %+v", entry)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	store, err := openSQLiteStore(ctx, strings.TrimPrefix(cfg.URL, sqliteURLPrefix))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	var transitions int
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM scholar_tier_history`).Scan(&transitions); err != nil {
+		t.Fatalf("count transitions: %v", err)
+	}
+	if transitions != 3 {
+		t.Fatalf("expected 2 first sightings plus 1 change, got %d", transitions)
+	}
+	var fromTier, tierSince string
+	if err := store.db.QueryRowContext(ctx, `
+		SELECT h.from_tier, g.tier_since
+		FROM scholar_tier_history h
+		JOIN audit_scholar_gaps g ON g.run_id = h.run_id AND g.scholar_id = h.scholar_id
+		WHERE h.run_id = ?`, last.RunID).Scan(&fromTier, &tierSince); err != nil {
+		t.Fatalf("read transition: %v", err)
+	}
+	if fromTier != "on_track" || tierSince != "2026-03-15" {
+		t.Fatalf("unexpected transition from=%s since=%s", fromTier, tierSince)
+	}
+
+	// A run under another tag starts its own tier clocks.
+	tagged := cfg
+	tagged.Tag = "adhoc"
+	report, err := buildReport([]string{file.Name()}, AuditOptions{AsOf: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	adhoc, err := storeReportInDB(report, tagged)
+	if err != nil {
+		t.Fatalf("store tagged run: %v", err)
+	}
+	if since := formatDate(adhoc.TierSince["S-2"]); since != "2026-03-20" {
+		t.Fatalf("expected the adhoc tag to ignore untagged history for S-2, got %s", since)
+	}
+}
+
+func TestBuildReportAcks(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/touchpoints.csv"
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2026-01-20,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-09-01,Bridge,Call,Reached,Chen\n" +
+		"S-3,2025-09-15,Bridge,Text,Reached,Chen\n"
+	if err := os.WriteFile(input, []byte(csvData), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	acksPath := dir + "/acks.csv"
+	acksData := "scholar_id,snooze_until,reason,acked_by\n" +
+		"S-2,2026-01-10,Left voicemail,Chen\n" +
+		"S-2,2026-02-15,Approved pause,Chen\n" +
+		"S-3,2026-01-15,Family emergency,Chen\n"
+	if err := os.WriteFile(acksPath, []byte(acksData), 0o644); err != nil {
+		t.Fatalf("write acks: %v", err)
+	}
+
+	acks, err := loadAcks(acksPath, nil)
+	if err != nil {
+		t.Fatalf("load acks: %v", err)
+	}
+	if ack := acks["S-2"]; formatDate(ack.SnoozeUntil) != "2026-02-15" || ack.Reason != "Approved pause" {
+		t.Fatalf("expected the latest S-2 snooze to win, got %+v", ack)
+	}
+
+	opts := AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5}
+	plain, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+
+	opts.Acks = acks
+	suppressed, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build suppressed report: %v", err)
+	}
+	if len(suppressed.Scholars) != 2 || len(suppressed.Snoozed) != 1 || suppressed.Snoozed[0].ScholarID != "S-2" {
+		t.Fatalf("expected S-2 to move to the snoozed list, got scholars=%d snoozed=%+v", len(suppressed.Scholars), suppressed.Snoozed)
+	}
+	if suppressed.Summary.SnoozedScholars != 1 || suppressed.Summary.SnoozeReasons["Approved pause"] != 1 || suppressed.Summary.SnoozeMode != snoozeModeSuppress {
+		t.Fatalf("unexpected snooze summary: %+v", suppressed.Summary)
+	}
+	if got, want := tierCount(suppressed.Summary.TierCounts, "critical"), tierCount(plain.Summary.TierCounts, "critical")-1; got != want {
+		t.Fatalf("expected snoozed scholar out of critical count, got %d want %d", got, want)
+	}
+	for _, entry := range suppressed.TopGaps {
+		if entry.ScholarID == "S-2" {
+			t.Fatalf("expected snoozed scholar out of top gaps")
+		}
+	}
+	if suppressed.Summary.InputFingerprint == plain.Summary.InputFingerprint {
+		t.Fatalf("expected acks to change the fingerprint")
+	}
+
+	opts.SnoozeMode = snoozeModeMark
+	marked, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build marked report: %v", err)
+	}
+	if len(marked.Scholars) != 3 || len(marked.Snoozed) != 0 || marked.Summary.SnoozedScholars != 1 {
+		t.Fatalf("expected mark mode to keep every scholar, got %d scholars", len(marked.Scholars))
+	}
+	alerts := dir + "/alerts.csv"
+	if err := writeAlertsCSV(marked, alerts, "overdue"); err != nil {
+		t.Fatalf("write alerts: %v", err)
+	}
+	data, err := os.ReadFile(alerts)
+	if err != nil {
+		t.Fatalf("read alerts: %v", err)
+	}
+	if !strings.Contains(string(data), "snoozed_until,snooze_reason,acked_by") || !strings.Contains(string(data), "2026-02-15,Approved pause,Chen") {
+		t.Fatalf("expected marked alert row with snooze details, got:\n%s", data)
+	}
+	if strings.Contains(string(data), "Family emergency") {
+		t.Fatalf("expected expired snooze to be ignored, got:\n%s", data)
+	}
+	if err := writeAlertsCSV(plain, alerts, "overdue"); err != nil {
+		t.Fatalf("write plain alerts: %v", err)
+	}
+	if data, err = os.ReadFile(alerts); err != nil {
+		t.Fatalf("read plain alerts: %v", err)
+	}
+	if strings.Contains(string(data), "snoozed_until") || strings.Contains(string(data), "acked_by") {
+		t.Fatalf("expected no snooze columns without acks, got:\n%s", data)
+	}
+}
+
+func TestPlanAlerts(t *testing.T) {
+	tiers := []string{"on_track", "due_soon", "overdue", "critical"}
+	first := Report{
+		Tiers:   tiers,
+		Summary: ReportSummary{AsOf: "2026-02-01"},
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Tier: "overdue"},
+			{ScholarID: "S-2", Tier: "critical"},
+			{ScholarID: "S-3", Tier: "on_track"},
+		},
+	}
+	reasons, changes, state, err := planAlerts(first, AlertState{Scholars: map[string]AlertStateEntry{}}, "overdue", 14)
+	if err != nil {
+		t.Fatalf("plan first alerts: %v", err)
+	}
+	if len(reasons) != 2 || reasons["S-1"] != alertReasonNew || reasons["S-2"] != alertReasonNew || changes.New != 2 {
+		t.Fatalf("expected both alert-tier scholars to be new, got %v %+v", reasons, changes)
+	}
+	if state.Scholars["S-1"].AlertedOn != "2026-02-01" || state.Scholars["S-3"].AlertedOn != "" {
+		t.Fatalf("unexpected saved state: %+v", state.Scholars)
+	}
+
+	second := Report{
+		Tiers:   tiers,
+		Summary: ReportSummary{AsOf: "2026-02-08"},
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Tier: "critical"},
+			{ScholarID: "S-2", Tier: "critical"},
+			{ScholarID: "S-3", Tier: "overdue"},
+		},
+	}
+	reasons, changes, _, err = planAlerts(second, state, "overdue", 0)
+	if err != nil {
+		t.Fatalf("plan second alerts: %v", err)
+	}
+	if reasons["S-1"] != alertReasonEscalated || reasons["S-3"] != alertReasonNew || len(reasons) != 2 {
+		t.Fatalf("expected an escalation and a new entry, got %v", reasons)
+	}
+	if changes != (AlertChanges{New: 1, Escalated: 1, Unchanged: 1}) {
+		t.Fatalf("unexpected changes without cooldown: %+v", changes)
+	}
+
+	reasons, changes, next, err := planAlerts(second, state, "overdue", 14)
+	if err != nil {
+		t.Fatalf("plan cooldown alerts: %v", err)
+	}
+	if len(reasons) != 1 || reasons["S-3"] != alertReasonNew || changes.Cooldown != 1 {
+		t.Fatalf("expected S-1 held by the cooldown, got %v %+v", reasons, changes)
+	}
+	if next.Scholars["S-1"].AlertedOn != "2026-02-01" || next.Scholars["S-1"].Tier != "overdue" {
+		t.Fatalf("expected cooldown to keep the last alerted tier and date, got %+v", next.Scholars["S-1"])
+	}
+
+	third := second
+	third.Summary.AsOf = "2026-02-20"
+	reasons, changes, _, err = planAlerts(third, next, "overdue", 14)
+	if err != nil {
+		t.Fatalf("plan post-cooldown alerts: %v", err)
+	}
+	if reasons["S-1"] != alertReasonEscalated || changes.Escalated != 1 {
+		t.Fatalf("expected the held escalation to fire after the cooldown, got %v %+v", reasons, changes)
+	}
+
+	lowered := Report{
+		Tiers:   tiers,
+		Summary: ReportSummary{AsOf: "2026-02-08"},
+		Scholars: []ScholarSummary{
+			{ScholarID: "S-1", Tier: "overdue"},
+			{ScholarID: "S-2", Tier: "critical"},
+		},
+	}
+	criticalOnly := AlertState{AsOf: "2026-02-01", MinTier: "critical", Scholars: map[string]AlertStateEntry{
+		"S-1": {Tier: "overdue"},
+		"S-2": {Tier: "critical", AlertedOn: "2026-02-01"},
+	}}
+	reasons, _, _, err = planAlerts(lowered, criticalOnly, "overdue", 0)
+	if err != nil {
+		t.Fatalf("plan lowered threshold: %v", err)
+	}
+	if len(reasons) != 1 || reasons["S-1"] != alertReasonNew {
+		t.Fatalf("expected lowering --min-tier to alert the newly included tier, got %v", reasons)
+	}
+
+	alerted := onlyAlerted(second, reasons)
+	if len(alerted.Scholars) != 1 || alerted.Scholars[0].AlertReason != alertReasonNew {
+		t.Fatalf("expected only the alerted scholar with its reason, got %+v", alerted.Scholars)
+	}
+
+	if _, _, _, err := planAlerts(first, next, "overdue", 0); err == nil {
+		t.Fatalf("expected an error for state newer than the as-of date")
+	}
+
+	path := t.TempDir() + "/alert-state.json"
+	missing, err := loadAlertState(path)
+	if err != nil || len(missing.Scholars) != 0 {
+		t.Fatalf("expected a missing state file to load empty, got %+v (%v)", missing, err)
+	}
+	if err := writeAlertState(next, path); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+	loaded, err := loadAlertState(path)
+	if err != nil || loaded.AsOf != "2026-02-08" || len(loaded.Scholars) != 3 {
+		t.Fatalf("expected the saved state back, got %+v (%v)", loaded, err)
+	}
+}
+
+func TestBuildReportExclusions(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/touchpoints.csv"
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2025-12-01,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-09-01,Bridge,Call,Reached,Chen\n" +
+		"S-3,2026-01-20,Bridge,Text,Reached,Chen\n"
+	if err := os.WriteFile(input, []byte(csvData), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	exclusionsPath := dir + "/exclusions.csv"
+	exclusionsData := "scholar_id,start_date,end_date,reason\n" +
+		"S-1,2025-12-10,2026-01-09,Medical leave\n" +
+		"S-2,2025-10-01,,Withdrawn\n" +
+		"S-3,2026-03-01,,Graduated\n"
+	if err := os.WriteFile(exclusionsPath, []byte(exclusionsData), 0o644); err != nil {
+		t.Fatalf("write exclusions: %v", err)
+	}
+
+	exclusions, err := loadExclusions(exclusionsPath, nil)
+	if err != nil {
+		t.Fatalf("load exclusions: %v", err)
+	}
+	opts := AuditOptions{AsOf: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), CadenceDays: 30, DueWindowDays: 15, TopN: 5}
+	plain, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	opts.Exclusions = exclusions
+	report, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build excluded report: %v", err)
+	}
+
+	if report.Summary.TotalScholars != 2 || report.Summary.ExcludedScholars != 1 || report.Summary.ExcludedReasons["Withdrawn"] != 1 {
+		t.Fatalf("expected the withdrawn scholar excluded, got %+v", report.Summary)
+	}
+	if len(report.Excluded) != 1 || report.Excluded[0].ScholarID != "S-2" || formatDate(report.Excluded[0].Start) != "2025-10-01" {
+		t.Fatalf("unexpected excluded section: %+v", report.Excluded)
+	}
+	for _, entry := range report.Scholars {
+		switch entry.ScholarID {
+		case "S-1":
+			if entry.GapDays != 31 || entry.PausedDays != 31 || formatDate(entry.NextDueDate) != "2026-01-31" {
+				t.Fatalf("expected the ended leave to pause the clock, got gap=%d paused=%d next_due=%s", entry.GapDays, entry.PausedDays, formatDate(entry.NextDueDate))
+			}
+		case "S-3":
+			if entry.PausedDays != 0 || entry.GapDays != 12 {
+				t.Fatalf("expected a future exclusion to be ignored, got %+v", entry)
+			}
+		}
+	}
+	if report.Summary.InputFingerprint == plain.Summary.InputFingerprint {
+		t.Fatalf("expected exclusions to change the fingerprint")
+	}
+
+	bad := dir + "/bad.csv"
+	if err := os.WriteFile(bad, []byte("scholar_id,start_date,end_date\nS-1,2026-02-01,2026-01-01\n"), 0o644); err != nil {
+		t.Fatalf("write bad exclusions: %v", err)
+	}
+	if _, err := loadExclusions(bad, nil); err == nil {
+		t.Fatalf("expected an end date before the start date to be rejected")
+	}
+}
+
+func TestBuildReportCalendar(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/touchpoints.csv"
+	csvData := "scholar_id,contact_date,program,channel,status,owner\n" +
+		"S-1,2025-12-01,Launchpad,Email,Reached,Rivera\n" +
+		"S-2,2025-12-01,Bridge,Call,Reached,Chen\n"
+	if err := os.WriteFile(input, []byte(csvData), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	calendarPath := dir + "/calendar.csv"
+	calendarData := "name,program,start_date,end_date\n" +
+		"Winter recess,launchpad,2025-12-20,2026-01-04\n" +
+		"Spring break,,2026-03-09,2026-03-13\n"
+	if err := os.WriteFile(calendarPath, []byte(calendarData), 0o644); err != nil {
+		t.Fatalf("write calendar: %v", err)
+	}
+
+	calendar, err := loadCalendar(calendarPath, nil)
+	if err != nil {
+		t.Fatalf("load calendar: %v", err)
+	}
+	if len(calendar.windowsFor("Launchpad")) != 2 || len(calendar.windowsFor("Bridge")) != 1 {
+		t.Fatalf("expected program-specific and shared windows, got %+v", calendar)
+	}
+
+	report, err := buildReport([]string{input}, AuditOptions{
+		AsOf:          time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		CadenceDays:   30,
+		DueWindowDays: 15,
+		TopN:          5,
+		Calendar:      calendar,
+	})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	for _, entry := range report.Scholars {
+		switch entry.ScholarID {
+		case "S-1":
+			if entry.RawGapDays != 62 || entry.GapDays != 46 || entry.PausedDays != 16 || entry.DaysPastDue != 16 {
+				t.Fatalf("expected winter recess skipped, got raw=%d gap=%d paused=%d past_due=%d", entry.RawGapDays, entry.GapDays, entry.PausedDays, entry.DaysPastDue)
+			}
+			if formatDate(entry.NextDueDate) != "2026-01-16" {
+				t.Fatalf("expected next due date pushed past the recess, got %s", formatDate(entry.NextDueDate))
+			}
+		case "S-2":
+			if entry.RawGapDays != 62 || entry.GapDays != 62 || formatDate(entry.NextDueDate) != "2025-12-31" {
+				t.Fatalf("expected other programs unaffected, got %+v", entry)
+			}
+		}
+	}
+	if report.Summary.BlackoutPeriods != 2 || len(report.Parameters.Calendar) != 2 {
+		t.Fatalf("expected the calendar recorded with the run, got %+v", report.Parameters)
+	}
+
+	alerts := dir + "/alerts.csv"
+	if err := writeAlertsCSV(report, alerts, "due_soon"); err != nil {
+		t.Fatalf("write alerts: %v", err)
+	}
+	data, err := os.ReadFile(alerts)
+	if err != nil {
+		t.Fatalf("read alerts: %v", err)
+	}
+	if !strings.Contains(string(data), "gap_days,raw_gap_days,paused_days") || !strings.Contains(string(data), ",46,62,16,") {
+		t.Fatalf("expected raw and adjusted gaps in alerts, got:\n%s", data)
+	}
+
+	bad := dir + "/bad.csv"
+	if err := os.WriteFile(bad, []byte("start_date,end_date\n2026-03-09,\n"), 0o644); err != nil {
+		t.Fatalf("write bad calendar: %v", err)
+	}
+	if _, err := loadCalendar(bad, nil); err == nil {
+		t.Fatalf("expected a blackout without an end date to be rejected")
+	}
+}
+
+func TestBuildReportBusinessDays(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/touchpoints.csv"
+	csvData := "scholar_id,contact_date,program,channel,status\n" +
+		"S-1,2025-12-31,Launchpad,Email,Reached\n" +
+		"S-2,2026-01-05,Launchpad,Call,Reached\n"
+	if err := os.WriteFile(input, []byte(csvData), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	holidaysPath := dir + "/holidays.csv"
+	holidaysData := "date,name\n" +
+		"2026-01-19,MLK Day\n" +
+		"2026-01-01,New Year's Day\n"
+	if err := os.WriteFile(holidaysPath, []byte(holidaysData), 0o644); err != nil {
+		t.Fatalf("write holidays: %v", err)
+	}
+
+	holidays, err := loadHolidays(holidaysPath, nil)
+	if err != nil {
+		t.Fatalf("load holidays: %v", err)
+	}
+	if len(holidays) != 2 || formatDate(holidays[0].Date) != "2026-01-01" || holidays[0].Name != "New Year's Day" {
+		t.Fatalf("expected holidays sorted by date, got %+v", holidays)
+	}
+
+	opts := AuditOptions{
+		AsOf:          time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		CadenceDays:   5,
+		DueWindowDays: 2,
+		TopN:          5,
+		BusinessDays:  true,
+		Holidays:      holidays,
+	}
+	report, err := buildReport([]string{input}, opts)
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	for _, entry := range report.Scholars {
+		switch entry.ScholarID {
+		case "S-1":
+			// Jan 2, 5, 6, 7, 8, 9, 12 are working days; Jan 1 is a holiday.
+			if entry.RawGapDays != 12 || entry.GapDays != 7 || entry.PausedDays != 0 || entry.DaysPastDue != 2 {
+				t.Fatalf("expected weekends and holidays skipped, got raw=%d gap=%d paused=%d past_due=%d", entry.RawGapDays, entry.GapDays, entry.PausedDays, entry.DaysPastDue)
+			}
+			if formatDate(entry.NextDueDate) != "2026-01-08" {
+				t.Fatalf("expected next due date after five working days, got %s", formatDate(entry.NextDueDate))
+			}
+		case "S-2":
+			if entry.GapDays != 5 || formatDate(entry.NextDueDate) != "2026-01-12" {
+				t.Fatalf("expected next due date moved off the weekend, got gap=%d next_due=%s", entry.GapDays, formatDate(entry.NextDueDate))
+			}
+			if weekday := entry.NextDueDate.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+				t.Fatalf("expected next due date on a working day, got %s", weekday)
+			}
+		}
+	}
+	if !report.Summary.BusinessDays || len(report.Parameters.Holidays) != 2 {
+		t.Fatalf("expected business-day mode recorded with the run, got %+v", report.Parameters)
+	}
+
+	calendarOpts := opts
+	calendarOpts.BusinessDays = false
+	calendarOpts.Holidays = nil
+	calendarReport, err := buildReport([]string{input}, calendarOpts)
+	if err != nil {
+		t.Fatalf("build calendar report: %v", err)
+	}
+	if report.Summary.InputFingerprint == calendarReport.Summary.InputFingerprint {
+		t.Fatalf("expected business-day mode to change the fingerprint")
+	}
+	for _, entry := range calendarReport.Scholars {
+		if entry.ScholarID == "S-2" && formatDate(entry.NextDueDate) != "2026-01-10" {
+			t.Fatalf("expected calendar-day mode unchanged, got %s", formatDate(entry.NextDueDate))
+		}
+	}
+
+	bad := dir + "/bad.csv"
+	if err := os.WriteFile(bad, []byte("date,name\nnot-a-date,Oops\n"), 0o644); err != nil {
+		t.Fatalf("write bad holidays: %v", err)
+	}
+	if _, err := loadHolidays(bad, nil); err == nil {
+		t.Fatalf("expected an unparseable holiday date to be rejected")
 	}
-	return diff < 0.01
 }
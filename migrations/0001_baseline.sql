@@ -0,0 +1,228 @@
+-- Baseline schema. Written idempotently so databases created before
+-- versioned migrations existed upgrade in place.
+
+CREATE SCHEMA IF NOT EXISTS {{schema}};
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_runs (
+	id uuid PRIMARY KEY,
+	as_of date NOT NULL,
+	cadence_days integer NOT NULL,
+	due_window_days integer NOT NULL,
+	total_scholars integer NOT NULL,
+	avg_gap_days numeric(8,2) NOT NULL,
+	median_gap_days numeric(8,2) NOT NULL,
+	max_gap_days integer NOT NULL,
+	avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0,
+	max_missed_cadences integer NOT NULL DEFAULT 0,
+	on_track_count integer NOT NULL,
+	due_soon_count integer NOT NULL,
+	overdue_count integer NOT NULL,
+	critical_count integer NOT NULL,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	invalid_rows integer NOT NULL,
+	future_rows integer NOT NULL DEFAULT 0,
+	failed_attempts integer NOT NULL DEFAULT 0,
+	schedule_future boolean NOT NULL DEFAULT false,
+	run_tag text,
+	input_files text[] NOT NULL DEFAULT '{}',
+	timezone text,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS future_rows integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS max_missed_cadences integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS never_contacted_count integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS failed_attempts integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS input_files text[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS timezone text;
+
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS schedule_future boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_scholar_gaps (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	scholar_id text NOT NULL,
+	program text,
+	owner text,
+	last_channel text,
+	last_status text,
+	enrollment_date date,
+	last_contact date,
+	last_successful_contact date,
+	first_contact date,
+	next_due_date date,
+	scheduled_date date,
+	contact_count integer NOT NULL,
+	failed_attempts integer NOT NULL DEFAULT 0,
+	attempts_since_success integer NOT NULL DEFAULT 0,
+	consecutive_failed_attempts integer NOT NULL DEFAULT 0,
+	gap_days integer NOT NULL,
+	days_past_due integer NOT NULL,
+	missed_cadences integer NOT NULL DEFAULT 0,
+	cadence_days integer,
+	due_window_days integer,
+	days_since_first_contact integer NOT NULL DEFAULT 0,
+	avg_interval_days numeric(8,2) NOT NULL DEFAULT 0,
+	contacts_per_month numeric(8,2) NOT NULL DEFAULT 0,
+	tier text NOT NULL,
+	tier_rank integer NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS first_contact date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS next_due_date date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS days_past_due integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS days_since_first_contact integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS avg_interval_days numeric(8,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS contacts_per_month numeric(8,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS missed_cadences integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS owner text;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS enrollment_date date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS tier_rank integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS last_successful_contact date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS scheduled_date date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS failed_attempts integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS attempts_since_success integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS consecutive_failed_attempts integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS cadence_days integer;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS due_window_days integer;
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_program_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	program text NOT NULL,
+	cadence_days integer,
+	due_window_days integer,
+	scholars integer NOT NULL,
+	avg_gap_days numeric(8,2) NOT NULL,
+	avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0,
+	on_track_count integer NOT NULL,
+	due_soon_count integer NOT NULL,
+	overdue_count integer NOT NULL,
+	critical_count integer NOT NULL,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE {{schema}}.audit_program_summary
+ADD COLUMN IF NOT EXISTS avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_program_summary
+ADD COLUMN IF NOT EXISTS never_contacted_count integer NOT NULL DEFAULT 0;
+
+ALTER TABLE {{schema}}.audit_program_summary
+ADD COLUMN IF NOT EXISTS cadence_days integer;
+
+ALTER TABLE {{schema}}.audit_program_summary
+ADD COLUMN IF NOT EXISTS due_window_days integer;
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_owner_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	owner text NOT NULL,
+	scholars integer NOT NULL,
+	avg_gap_days numeric(8,2) NOT NULL,
+	avg_missed_cadences numeric(8,2) NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_channel_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	channel text NOT NULL,
+	touchpoint_count integer NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_status_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	status text NOT NULL,
+	touchpoint_count integer NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_tier_counts (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	scope text NOT NULL,
+	scope_key text,
+	tier text NOT NULL,
+	tier_rank integer NOT NULL,
+	scholar_count integer NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_recency_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	label text NOT NULL,
+	min_days integer,
+	max_days integer,
+	bucket_count integer NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_scholar_gaps_run_idx ON {{schema}}.audit_scholar_gaps (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_scholar_gaps_tier_idx ON {{schema}}.audit_scholar_gaps (tier);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_program_summary_run_idx ON {{schema}}.audit_program_summary (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_owner_summary_run_idx ON {{schema}}.audit_owner_summary (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_channel_summary_run_idx ON {{schema}}.audit_channel_summary (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_status_summary_run_idx ON {{schema}}.audit_status_summary (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_tier_counts_run_idx ON {{schema}}.audit_tier_counts (run_id);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_recency_summary_run_idx ON {{schema}}.audit_recency_summary (run_id);
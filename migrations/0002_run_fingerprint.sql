@@ -0,0 +1,6 @@
+-- Fingerprint of the input files and audit parameters, used to skip or
+-- replace repeat stores of the same audit.
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS input_fingerprint text;
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_runs_fingerprint_idx ON {{schema}}.audit_runs (input_fingerprint);
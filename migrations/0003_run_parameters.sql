@@ -0,0 +1,22 @@
+-- Remaining run parameters and the due-date buckets, so a stored run can
+-- be reconstructed without the original command line.
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS dedupe_day boolean NOT NULL DEFAULT false,
+ADD COLUMN IF NOT EXISTS top_n integer,
+ADD COLUMN IF NOT EXISTS min_tier text,
+ADD COLUMN IF NOT EXISTS tool_version text,
+ADD COLUMN IF NOT EXISTS success_statuses text[] NOT NULL DEFAULT '{}',
+ADD COLUMN IF NOT EXISTS tiers text[] NOT NULL DEFAULT '{}',
+ADD COLUMN IF NOT EXISTS parameters jsonb;
+
+CREATE TABLE IF NOT EXISTS {{schema}}.audit_due_summary (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	label text NOT NULL,
+	min_days integer,
+	max_days integer,
+	bucket_count integer NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_audit_due_summary_run_idx ON {{schema}}.audit_due_summary (run_id);
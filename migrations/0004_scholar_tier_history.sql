@@ -0,0 +1,33 @@
+-- One row per scholar tier change, so "when did this scholar become
+-- critical" is an index lookup instead of a self-join over every run.
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS tier_since date;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS days_in_tier integer;
+
+CREATE TABLE IF NOT EXISTS {{schema}}.scholar_tier_history (
+	id uuid PRIMARY KEY,
+	run_id uuid NOT NULL REFERENCES {{schema}}.audit_runs(id) ON DELETE CASCADE,
+	scholar_id text NOT NULL,
+	from_tier text,
+	to_tier text NOT NULL,
+	as_of date NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS {{schema}}_scholar_tier_history_scholar_idx ON {{schema}}.scholar_tier_history (scholar_id, as_of);
+CREATE INDEX IF NOT EXISTS {{schema}}_scholar_tier_history_run_idx ON {{schema}}.scholar_tier_history (run_id);
+
+-- Backfill transitions from runs stored before this table existed, walking
+-- each scholar's snapshots in as-of order.
+INSERT INTO {{schema}}.scholar_tier_history (id, run_id, scholar_id, from_tier, to_tier, as_of, created_at)
+SELECT md5(gap_id::text || ':tier')::uuid, run_id, scholar_id, previous_tier, tier, as_of, created_at
+FROM (
+	SELECT g.id AS gap_id, g.run_id, g.scholar_id, g.tier, r.as_of, r.created_at,
+		LAG(g.tier) OVER (PARTITION BY g.scholar_id ORDER BY r.as_of, r.created_at) AS previous_tier
+	FROM {{schema}}.audit_scholar_gaps g
+	JOIN {{schema}}.audit_runs r ON r.id = g.run_id
+) snapshots
+WHERE previous_tier IS DISTINCT FROM tier
+ON CONFLICT (id) DO NOTHING;
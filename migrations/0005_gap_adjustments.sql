@@ -0,0 +1,7 @@
+-- Calendar-day gap before exclusion and blackout pauses, alongside the
+-- adjusted gap_days, and how many days were paused.
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS raw_gap_days integer;
+
+ALTER TABLE {{schema}}.audit_scholar_gaps
+ADD COLUMN IF NOT EXISTS paused_days integer NOT NULL DEFAULT 0;
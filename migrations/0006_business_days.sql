@@ -0,0 +1,3 @@
+-- Whether cadence, gaps, and due dates were counted in working days.
+ALTER TABLE {{schema}}.audit_runs
+ADD COLUMN IF NOT EXISTS business_days boolean NOT NULL DEFAULT false;
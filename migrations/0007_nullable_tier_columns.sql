@@ -0,0 +1,25 @@
+-- The fixed on_track through critical count columns only describe the
+-- default ladder. Runs with a custom ladder store NULL there and keep their
+-- real counts in audit_tier_counts.
+ALTER TABLE {{schema}}.audit_runs
+ALTER COLUMN on_track_count DROP NOT NULL,
+ALTER COLUMN due_soon_count DROP NOT NULL,
+ALTER COLUMN overdue_count DROP NOT NULL,
+ALTER COLUMN critical_count DROP NOT NULL;
+
+ALTER TABLE {{schema}}.audit_program_summary
+ALTER COLUMN on_track_count DROP NOT NULL,
+ALTER COLUMN due_soon_count DROP NOT NULL,
+ALTER COLUMN overdue_count DROP NOT NULL,
+ALTER COLUMN critical_count DROP NOT NULL;
+
+-- Clear the zeros already written for custom-ladder runs.
+UPDATE {{schema}}.audit_runs
+SET on_track_count = NULL, due_soon_count = NULL, overdue_count = NULL, critical_count = NULL
+WHERE cardinality(tiers) > 0
+	AND array_remove(tiers, 'scheduled') <> ARRAY['on_track', 'due_soon', 'overdue', 'critical', 'never_contacted'];
+
+UPDATE {{schema}}.audit_program_summary p
+SET on_track_count = NULL, due_soon_count = NULL, overdue_count = NULL, critical_count = NULL
+FROM {{schema}}.audit_runs r
+WHERE r.id = p.run_id AND r.on_track_count IS NULL;
@@ -0,0 +1,34 @@
+-- Tier history is tracked per run_tag, so a nightly run and an ad-hoc run
+-- of a different population no longer restart each other's tier clocks.
+-- Rebuild the transitions from the stored snapshots with that partition.
+DELETE FROM {{schema}}.scholar_tier_history;
+
+INSERT INTO {{schema}}.scholar_tier_history (id, run_id, scholar_id, from_tier, to_tier, as_of, created_at)
+SELECT md5(gap_id::text || ':tier')::uuid, run_id, scholar_id, previous_tier, tier, as_of, created_at
+FROM (
+	SELECT g.id AS gap_id, g.run_id, g.scholar_id, g.tier, r.as_of, r.created_at,
+		LAG(g.tier) OVER (PARTITION BY g.scholar_id, r.run_tag ORDER BY r.as_of, r.created_at) AS previous_tier
+	FROM {{schema}}.audit_scholar_gaps g
+	JOIN {{schema}}.audit_runs r ON r.id = g.run_id
+) snapshots
+WHERE previous_tier IS DISTINCT FROM tier;
+
+-- Re-derive tier_since for scholar rows that already carried one.
+UPDATE {{schema}}.audit_scholar_gaps g
+SET tier_since = (
+	SELECT h.as_of
+	FROM {{schema}}.scholar_tier_history h
+	JOIN {{schema}}.audit_runs hr ON hr.id = h.run_id
+	WHERE h.scholar_id = g.scholar_id
+		AND hr.run_tag IS NOT DISTINCT FROM r.run_tag
+		AND h.as_of <= r.as_of
+	ORDER BY h.as_of DESC, h.created_at DESC
+	LIMIT 1
+)
+FROM {{schema}}.audit_runs r
+WHERE r.id = g.run_id AND g.tier_since IS NOT NULL;
+
+UPDATE {{schema}}.audit_scholar_gaps g
+SET days_in_tier = r.as_of - g.tier_since
+FROM {{schema}}.audit_runs r
+WHERE r.id = g.run_id AND g.tier_since IS NOT NULL;
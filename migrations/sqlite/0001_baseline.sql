@@ -0,0 +1,152 @@
+-- SQLite baseline matching Postgres migrations 0001-0003. Dates are
+-- YYYY-MM-DD text, arrays and parameters are JSON text, and booleans are 0/1.
+
+CREATE TABLE IF NOT EXISTS audit_runs (
+	id text PRIMARY KEY,
+	as_of text NOT NULL,
+	cadence_days integer NOT NULL,
+	due_window_days integer NOT NULL,
+	total_scholars integer NOT NULL,
+	avg_gap_days real NOT NULL,
+	median_gap_days real NOT NULL,
+	max_gap_days integer NOT NULL,
+	avg_missed_cadences real NOT NULL DEFAULT 0,
+	max_missed_cadences integer NOT NULL DEFAULT 0,
+	on_track_count integer NOT NULL,
+	due_soon_count integer NOT NULL,
+	overdue_count integer NOT NULL,
+	critical_count integer NOT NULL,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	invalid_rows integer NOT NULL,
+	future_rows integer NOT NULL DEFAULT 0,
+	failed_attempts integer NOT NULL DEFAULT 0,
+	schedule_future integer NOT NULL DEFAULT 0,
+	run_tag text,
+	input_files text NOT NULL DEFAULT '[]',
+	timezone text,
+	input_fingerprint text,
+	dedupe_day integer NOT NULL DEFAULT 0,
+	top_n integer,
+	min_tier text,
+	tool_version text,
+	success_statuses text NOT NULL DEFAULT '[]',
+	tiers text NOT NULL DEFAULT '[]',
+	parameters text,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_scholar_gaps (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	scholar_id text NOT NULL,
+	program text,
+	owner text,
+	last_channel text,
+	last_status text,
+	enrollment_date text,
+	last_contact text,
+	last_successful_contact text,
+	first_contact text,
+	next_due_date text,
+	scheduled_date text,
+	contact_count integer NOT NULL,
+	failed_attempts integer NOT NULL DEFAULT 0,
+	attempts_since_success integer NOT NULL DEFAULT 0,
+	consecutive_failed_attempts integer NOT NULL DEFAULT 0,
+	gap_days integer NOT NULL,
+	days_past_due integer NOT NULL,
+	missed_cadences integer NOT NULL DEFAULT 0,
+	cadence_days integer,
+	due_window_days integer,
+	days_since_first_contact integer NOT NULL DEFAULT 0,
+	avg_interval_days real NOT NULL DEFAULT 0,
+	contacts_per_month real NOT NULL DEFAULT 0,
+	tier text NOT NULL,
+	tier_rank integer NOT NULL DEFAULT 0,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_program_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	program text NOT NULL,
+	cadence_days integer,
+	due_window_days integer,
+	scholars integer NOT NULL,
+	avg_gap_days real NOT NULL,
+	avg_missed_cadences real NOT NULL DEFAULT 0,
+	on_track_count integer NOT NULL,
+	due_soon_count integer NOT NULL,
+	overdue_count integer NOT NULL,
+	critical_count integer NOT NULL,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_owner_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	owner text NOT NULL,
+	scholars integer NOT NULL,
+	avg_gap_days real NOT NULL,
+	avg_missed_cadences real NOT NULL DEFAULT 0,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_channel_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	channel text NOT NULL,
+	touchpoint_count integer NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_status_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	status text NOT NULL,
+	touchpoint_count integer NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_tier_counts (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	scope text NOT NULL,
+	scope_key text,
+	tier text NOT NULL,
+	tier_rank integer NOT NULL,
+	scholar_count integer NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_recency_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	label text NOT NULL,
+	min_days integer,
+	max_days integer,
+	bucket_count integer NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_due_summary (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	label text NOT NULL,
+	min_days integer,
+	max_days integer,
+	bucket_count integer NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_scholar_gaps_run_idx ON audit_scholar_gaps (run_id);
+CREATE INDEX IF NOT EXISTS audit_scholar_gaps_tier_idx ON audit_scholar_gaps (tier);
+CREATE INDEX IF NOT EXISTS audit_program_summary_run_idx ON audit_program_summary (run_id);
+CREATE INDEX IF NOT EXISTS audit_owner_summary_run_idx ON audit_owner_summary (run_id);
+CREATE INDEX IF NOT EXISTS audit_channel_summary_run_idx ON audit_channel_summary (run_id);
+CREATE INDEX IF NOT EXISTS audit_status_summary_run_idx ON audit_status_summary (run_id);
+CREATE INDEX IF NOT EXISTS audit_tier_counts_run_idx ON audit_tier_counts (run_id);
+CREATE INDEX IF NOT EXISTS audit_recency_summary_run_idx ON audit_recency_summary (run_id);
+CREATE INDEX IF NOT EXISTS audit_runs_fingerprint_idx ON audit_runs (input_fingerprint);
+CREATE INDEX IF NOT EXISTS audit_due_summary_run_idx ON audit_due_summary (run_id);
@@ -0,0 +1,26 @@
+-- SQLite counterpart of Postgres migration 0004.
+ALTER TABLE audit_scholar_gaps ADD COLUMN tier_since text;
+ALTER TABLE audit_scholar_gaps ADD COLUMN days_in_tier integer;
+
+CREATE TABLE IF NOT EXISTS scholar_tier_history (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	scholar_id text NOT NULL,
+	from_tier text,
+	to_tier text NOT NULL,
+	as_of text NOT NULL,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS scholar_tier_history_scholar_idx ON scholar_tier_history (scholar_id, as_of);
+CREATE INDEX IF NOT EXISTS scholar_tier_history_run_idx ON scholar_tier_history (run_id);
+
+INSERT INTO scholar_tier_history (id, run_id, scholar_id, from_tier, to_tier, as_of, created_at)
+SELECT lower(hex(randomblob(16))), run_id, scholar_id, previous_tier, tier, as_of, created_at
+FROM (
+	SELECT g.run_id, g.scholar_id, g.tier, r.as_of, r.created_at,
+		LAG(g.tier) OVER (PARTITION BY g.scholar_id ORDER BY r.as_of, r.created_at, r.rowid) AS previous_tier
+	FROM audit_scholar_gaps g
+	JOIN audit_runs r ON r.id = g.run_id
+) snapshots
+WHERE previous_tier IS NOT tier;
@@ -0,0 +1,3 @@
+-- SQLite counterpart of Postgres migration 0005.
+ALTER TABLE audit_scholar_gaps ADD COLUMN raw_gap_days integer;
+ALTER TABLE audit_scholar_gaps ADD COLUMN paused_days integer NOT NULL DEFAULT 0;
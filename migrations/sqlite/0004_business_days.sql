@@ -0,0 +1,2 @@
+-- SQLite counterpart of Postgres migration 0006.
+ALTER TABLE audit_runs ADD COLUMN business_days integer NOT NULL DEFAULT 0;
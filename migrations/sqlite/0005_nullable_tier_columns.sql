@@ -0,0 +1,83 @@
+-- SQLite counterpart of Postgres migration 0007. SQLite cannot drop NOT
+-- NULL in place, so both tables are rebuilt with foreign keys switched off
+-- by applySQLiteMigration.
+CREATE TABLE audit_runs_rebuilt (
+	id text PRIMARY KEY,
+	as_of text NOT NULL,
+	cadence_days integer NOT NULL,
+	due_window_days integer NOT NULL,
+	total_scholars integer NOT NULL,
+	avg_gap_days real NOT NULL,
+	median_gap_days real NOT NULL,
+	max_gap_days integer NOT NULL,
+	avg_missed_cadences real NOT NULL DEFAULT 0,
+	max_missed_cadences integer NOT NULL DEFAULT 0,
+	on_track_count integer,
+	due_soon_count integer,
+	overdue_count integer,
+	critical_count integer,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	invalid_rows integer NOT NULL,
+	future_rows integer NOT NULL DEFAULT 0,
+	failed_attempts integer NOT NULL DEFAULT 0,
+	schedule_future integer NOT NULL DEFAULT 0,
+	run_tag text,
+	input_files text NOT NULL DEFAULT '[]',
+	timezone text,
+	input_fingerprint text,
+	dedupe_day integer NOT NULL DEFAULT 0,
+	top_n integer,
+	min_tier text,
+	tool_version text,
+	success_statuses text NOT NULL DEFAULT '[]',
+	tiers text NOT NULL DEFAULT '[]',
+	parameters text,
+	business_days integer NOT NULL DEFAULT 0,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO audit_runs_rebuilt (rowid, id, as_of, cadence_days, due_window_days, total_scholars, avg_gap_days, median_gap_days, max_gap_days, avg_missed_cadences, max_missed_cadences, on_track_count, due_soon_count, overdue_count, critical_count, never_contacted_count, invalid_rows, future_rows, failed_attempts, schedule_future, run_tag, input_files, timezone, input_fingerprint, dedupe_day, top_n, min_tier, tool_version, success_statuses, tiers, parameters, business_days, created_at)
+SELECT rowid, id, as_of, cadence_days, due_window_days, total_scholars, avg_gap_days, median_gap_days, max_gap_days, avg_missed_cadences, max_missed_cadences, on_track_count, due_soon_count, overdue_count, critical_count, never_contacted_count, invalid_rows, future_rows, failed_attempts, schedule_future, run_tag, input_files, timezone, input_fingerprint, dedupe_day, top_n, min_tier, tool_version, success_statuses, tiers, parameters, business_days, created_at
+FROM audit_runs;
+
+DROP TABLE audit_runs;
+ALTER TABLE audit_runs_rebuilt RENAME TO audit_runs;
+CREATE INDEX IF NOT EXISTS audit_runs_fingerprint_idx ON audit_runs (input_fingerprint);
+
+CREATE TABLE audit_program_summary_rebuilt (
+	id text PRIMARY KEY,
+	run_id text NOT NULL REFERENCES audit_runs(id) ON DELETE CASCADE,
+	program text NOT NULL,
+	cadence_days integer,
+	due_window_days integer,
+	scholars integer NOT NULL,
+	avg_gap_days real NOT NULL,
+	avg_missed_cadences real NOT NULL DEFAULT 0,
+	on_track_count integer,
+	due_soon_count integer,
+	overdue_count integer,
+	critical_count integer,
+	never_contacted_count integer NOT NULL DEFAULT 0,
+	created_at text NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO audit_program_summary_rebuilt (id, run_id, program, cadence_days, due_window_days, scholars, avg_gap_days, avg_missed_cadences, on_track_count, due_soon_count, overdue_count, critical_count, never_contacted_count, created_at)
+SELECT id, run_id, program, cadence_days, due_window_days, scholars, avg_gap_days, avg_missed_cadences, on_track_count, due_soon_count, overdue_count, critical_count, never_contacted_count, created_at
+FROM audit_program_summary;
+
+DROP TABLE audit_program_summary;
+ALTER TABLE audit_program_summary_rebuilt RENAME TO audit_program_summary;
+CREATE INDEX IF NOT EXISTS audit_program_summary_run_idx ON audit_program_summary (run_id);
+
+-- Clear the zeros already written for custom-ladder runs.
+UPDATE audit_runs
+SET on_track_count = NULL, due_soon_count = NULL, overdue_count = NULL, critical_count = NULL
+WHERE tiers <> '[]'
+	AND tiers NOT IN (
+		'["on_track","due_soon","overdue","critical","never_contacted"]',
+		'["on_track","scheduled","due_soon","overdue","critical","never_contacted"]'
+	);
+
+UPDATE audit_program_summary
+SET on_track_count = NULL, due_soon_count = NULL, overdue_count = NULL, critical_count = NULL
+WHERE run_id IN (SELECT id FROM audit_runs WHERE on_track_count IS NULL);
@@ -0,0 +1,31 @@
+-- SQLite counterpart of Postgres migration 0008.
+DELETE FROM scholar_tier_history;
+
+INSERT INTO scholar_tier_history (id, run_id, scholar_id, from_tier, to_tier, as_of, created_at)
+SELECT lower(hex(randomblob(16))), run_id, scholar_id, previous_tier, tier, as_of, created_at
+FROM (
+	SELECT g.run_id, g.scholar_id, g.tier, r.as_of, r.created_at, r.rowid AS run_position,
+		LAG(g.tier) OVER (PARTITION BY g.scholar_id, r.run_tag ORDER BY r.as_of, r.created_at, r.rowid) AS previous_tier
+	FROM audit_scholar_gaps g
+	JOIN audit_runs r ON r.id = g.run_id
+) snapshots
+WHERE previous_tier IS NOT tier
+ORDER BY as_of, created_at, run_position;
+
+UPDATE audit_scholar_gaps
+SET tier_since = (
+	SELECT h.as_of
+	FROM scholar_tier_history h
+	JOIN audit_runs hr ON hr.id = h.run_id
+	JOIN audit_runs r ON r.id = audit_scholar_gaps.run_id
+	WHERE h.scholar_id = audit_scholar_gaps.scholar_id
+		AND hr.run_tag IS r.run_tag
+		AND h.as_of <= r.as_of
+	ORDER BY h.as_of DESC, h.created_at DESC, h.rowid DESC
+	LIMIT 1
+)
+WHERE tier_since IS NOT NULL;
+
+UPDATE audit_scholar_gaps
+SET days_in_tier = CAST(julianday((SELECT as_of FROM audit_runs WHERE id = audit_scholar_gaps.run_id)) - julianday(tier_since) AS integer)
+WHERE tier_since IS NOT NULL;